@@ -13,10 +13,45 @@ export interface Host {
     swapFree: number;
   }
   
+  export interface CPUTimes {
+    user: number;
+    system: number;
+    nice: number;
+    idle: number;
+    iowait: number;
+    irq: number;
+    softirq: number;
+    steal: number;
+  }
+  
   export interface CPU {
+    name: string;
     usage: number;
     model: string;
     cores: number;
+    user: number;
+    system: number;
+    nice: number;
+    idle: number;
+    iowait: number;
+    irq: number;
+    softirq: number;
+    steal: number;
+    times: CPUTimes;
+    interval: number;
+  }
+  
+  export interface Load {
+    load1: number;
+    load5: number;
+    load15: number;
+    procsRunning: number;
+    procsBlocked: number;
+    totalContextSwitches: number;
+    totalInterrupts: number;
+    contextSwitchesPerSec: number;
+    interruptsPerSec: number;
+    interval: number;
   }
   
   export interface Disk {
@@ -26,19 +61,152 @@ export interface Host {
     used: number;
     free: number;
     usedPercent: number;
+    inodesTotal: number;
+    inodesUsed: number;
+    inodesFree: number;
+    inodesUsedPercent: number;
+  }
+  
+  export interface DiskIO {
+    device: string;
+    mountpoints: string[] | null;
+    totalReadBytes: number;
+    totalWriteBytes: number;
+    totalReadCount: number;
+    totalWriteCount: number;
+    totalReadTimeMs: number;
+    totalWriteTimeMs: number;
+    totalIoTimeMs: number;
+    readBytesPerSec: number;
+    writeBytesPerSec: number;
+    readIops: number;
+    writeIops: number;
+    awaitMs: number;
+    utilPercent: number;
+    interval: number;
   }
   
   export interface Network {
     totalBytesSent: number;
     totalBytesRecv: number;
+    totalPacketsSent: number;
+    totalPacketsRecv: number;
+    totalErrorsIn: number;
+    totalErrorsOut: number;
+    totalDropsIn: number;
+    totalDropsOut: number;
     interfaceName: string;
+    bytesSentPerSec: number;
+    bytesRecvPerSec: number;
+    packetsSentPerSec: number;
+    packetsRecvPerSec: number;
+    errorsInPerSec: number;
+    errorsOutPerSec: number;
+    dropsInPerSec: number;
+    dropsOutPerSec: number;
+    interval: number;
+  }
+  
+  export interface Process {
+    pid: number;
+    name: string;
+    cmdline: string;
+    user: string;
+    cpuPercent: number;
+    rss: number;
+    threads: number;
+    openFds: number;
+  }
+  
+  export interface CollectorError {
+    collector: string;
+    target?: string;
+    reason: "error" | "timeout" | "panic";
+    message: string;
+    durationMs: number;
   }
   
   export interface Monitor {
     host: Host;
     memory: Memory;
     cpu: CPU[];
+    load: Load;
     disk: Disk[];
+    diskIO: DiskIO[];
     network: Network[];
+    processes: Process[];
+    errors: CollectorError[] | null;
   }
-  
\ No newline at end of file
+
+  export interface HistorySeries {
+    labels: Record<string, string>;
+    values: (number | null)[];
+    anomaly?: (number | null)[];
+  }
+  
+  export interface HistoryResult {
+    metric: string;
+    from: number;
+    to: number;
+    step: number;
+    timestamps: number[];
+    series: HistorySeries[];
+  }
+  
+
+  export type AlertStatus = "pending" | "firing" | "resolved";
+
+  export interface AlertState {
+    rule: string;
+    fingerprint: string;
+    metric: string;
+    labels: Record<string, string> | null;
+    severity: "info" | "warning" | "critical";
+    status: AlertStatus;
+    value: number;
+    peak: number;
+    threshold: number;
+    activeAt: string;
+    firedAt?: string;
+    resolvedAt?: string;
+    silencedBy?: string[];
+    ackedBy?: string;
+    ackNote?: string;
+    ackedAt?: string;
+    escalated?: string[];
+  }
+  
+  export interface AlertEvent {
+    time: string;
+    rule: string;
+    fingerprint: string;
+    status: AlertStatus | "inactive" | "acknowledged" | "unacknowledged" | "escalated";
+    severity: "info" | "warning" | "critical";
+    metric: string;
+    labels: Record<string, string> | null;
+    host: string;
+    value: number;
+    peak: number;
+    threshold: number;
+    activeAt: string;
+    silencedBy?: string[];
+    ackedBy?: string;
+    ackNote?: string;
+    escalation?: string;
+  }
+  
+  export interface Forecast {
+    metric: string;
+    labels: Record<string, string> | null;
+    value: number;
+    slope: number;
+    timeToFull?: number;
+    fullAt?: string;
+    r2: number;
+    points: number;
+  }
+  
+  export interface ForecastResult {
+    window: number;
+    forecasts: Forecast[];
+  }
\ No newline at end of file
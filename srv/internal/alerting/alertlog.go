@@ -0,0 +1,260 @@
+package alerting
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/LissaiDev/Delphos/pkg/logger"
+)
+
+// Event kinds recorded besides the alert states
+const (
+	EventInactive       = "inactive"       // A pending alert cleared before it fired
+	EventAcknowledged   = "acknowledged"   // Someone took a firing alert on
+	EventUnacknowledged = "unacknowledged" // An acknowledgement was withdrawn
+	EventEscalated      = "escalated"      // An escalation policy notified its handlers
+)
+
+// alertLogMaxEvents bounds the events kept in memory, however short the retention
+const alertLogMaxEvents = 100000
+
+// alertLogCompactInterval is the minimum time between rewrites of the log file
+const alertLogCompactInterval = time.Hour
+
+// Event is a state change of an alert
+type Event struct {
+	Time        time.Time         `json:"time"`                 // When the change happened
+	Rule        string            `json:"rule"`                 // Rule name
+	Fingerprint string            `json:"fingerprint"`          // Stable identifier of rule and labels
+	Status      string            `json:"status"`               // An alert state or one of the event kinds above
+	Severity    string            `json:"severity"`             // Rule severity
+	Metric      string            `json:"metric"`               // Evaluated metric
+	Labels      map[string]string `json:"labels"`               // Labels of the evaluated series
+	Host        string            `json:"host"`                 // Host the alert was raised on
+	Value       float64           `json:"value"`                // Value at the time of the change
+	Peak        float64           `json:"peak"`                 // Worst value observed so far
+	Threshold   float64           `json:"threshold"`            // Rule threshold
+	ActiveAt    time.Time         `json:"activeAt"`             // When the condition was first met
+	SilencedBy  []string          `json:"silencedBy,omitempty"` // Silences muting the alert when it fired
+	AckedBy     string            `json:"ackedBy,omitempty"`    // Who acknowledged the alert
+	AckNote     string            `json:"ackNote,omitempty"`    // Note left with the acknowledgement
+	Escalation  string            `json:"escalation,omitempty"` // Policy of an escalated event
+}
+
+// LogQuery selects events from the alert log
+// Empty fields match everything; results are ordered newest first
+type LogQuery struct {
+	Rule        string
+	Severity    string
+	Status      string
+	Fingerprint string
+	From        time.Time // Inclusive, zero for no lower bound
+	To          time.Time // Inclusive, zero for no upper bound
+	Limit       int       // Maximum number of events returned
+	Offset      int       // Number of matching events skipped
+}
+
+// AlertLog keeps the alert state changes within the retention period
+// Events are appended to a JSON lines file, when one is given, which is
+// rewritten from time to time to drop expired events
+type AlertLog struct {
+	logger      logger.BasicLogger
+	path        string // Empty keeps events in memory only
+	file        *os.File
+	retention   time.Duration
+	events      []*Event // Oldest first
+	lastCompact time.Time
+	mu          sync.Mutex
+}
+
+// OpenAlertLog opens the alert log at path, loading the events still within retention
+// Lines that cannot be parsed (e.g. cut short by a crash) are skipped
+func OpenAlertLog(log logger.BasicLogger, path string, retention time.Duration) (*AlertLog, error) {
+	l := &AlertLog{
+		logger:      log,
+		path:        path,
+		retention:   retention,
+		lastCompact: time.Now(),
+	}
+	if path == "" {
+		return l, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-retention)
+	skipped := 0
+	if file, err := os.Open(path); err == nil {
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			var event Event
+			if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+				skipped++
+				continue
+			}
+			if event.Time.Before(cutoff) {
+				continue
+			}
+			l.events = append(l.events, &event)
+		}
+		file.Close()
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if skipped > 0 {
+		log.Warn("Skipped unreadable alert log entries", map[string]interface{}{
+			"path":    path,
+			"skipped": skipped,
+		})
+	}
+
+	// Start from a clean file holding only the retained events
+	if err := l.compact(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Append records an event
+func (l *AlertLog) Append(event Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.events = append(l.events, &event)
+
+	expired := l.expired(event.Time)
+	if expired > 0 {
+		l.events = l.events[expired:]
+	}
+
+	if l.file == nil {
+		return
+	}
+	if expired > 0 && event.Time.Sub(l.lastCompact) >= alertLogCompactInterval {
+		if err := l.compact(); err != nil {
+			l.logger.Error("Failed to compact alert log", map[string]interface{}{
+				"path":  l.path,
+				"error": err.Error(),
+			})
+		}
+		return
+	}
+
+	data, err := json.Marshal(&event)
+	if err == nil {
+		_, err = l.file.Write(append(data, '\n'))
+	}
+	if err != nil {
+		l.logger.Error("Failed to write alert log", map[string]interface{}{
+			"path":  l.path,
+			"error": err.Error(),
+		})
+	}
+}
+
+// Query returns the matching events, newest first, and the number of matches before paging
+func (l *AlertLog) Query(q LogQuery) ([]Event, int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var (
+		events []Event
+		total  int
+	)
+	for i := len(l.events) - 1; i >= 0; i-- {
+		event := l.events[i]
+		if !q.matches(event) {
+			continue
+		}
+		total++
+		if total > q.Offset && len(events) < q.Limit {
+			events = append(events, *event)
+		}
+	}
+	return events, total
+}
+
+// matches reports whether an event satisfies the query filters
+func (q LogQuery) matches(event *Event) bool {
+	switch {
+	case q.Rule != "" && q.Rule != event.Rule:
+		return false
+	case q.Severity != "" && q.Severity != event.Severity:
+		return false
+	case q.Status != "" && q.Status != event.Status:
+		return false
+	case q.Fingerprint != "" && q.Fingerprint != event.Fingerprint:
+		return false
+	case !q.From.IsZero() && event.Time.Before(q.From):
+		return false
+	case !q.To.IsZero() && event.Time.After(q.To):
+		return false
+	}
+	return true
+}
+
+// Close closes the log file
+func (l *AlertLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// expired counts the leading events past retention or over the size bound; callers hold the lock
+func (l *AlertLog) expired(now time.Time) int {
+	cutoff := now.Add(-l.retention)
+	n := 0
+	for n < len(l.events) && (l.events[n].Time.Before(cutoff) || len(l.events)-n > alertLogMaxEvents) {
+		n++
+	}
+	return n
+}
+
+// compact rewrites the file with the events in memory and reopens it for appending; callers hold the lock
+func (l *AlertLog) compact() error {
+	tmp := l.path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for _, event := range l.events {
+		if err := encoder.Encode(event); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, l.path); err != nil {
+		return err
+	}
+
+	if l.file != nil {
+		l.file.Close()
+	}
+	l.file, err = os.OpenFile(l.path, os.O_APPEND|os.O_WRONLY, 0o644)
+	l.lastCompact = time.Now()
+	return err
+}
@@ -0,0 +1,202 @@
+package alerting
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronMacros are the shorthand schedules accepted in place of five fields
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronField describes the range and names of one schedule field
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names []string // Names for min, min+1, ... (months and weekdays)
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}},
+	{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}},
+}
+
+// cronSchedule is a parsed five field cron expression (minute hour day-of-month month day-of-week)
+// Like cron, when both day fields are restricted a time matches if either of them does
+type cronSchedule struct {
+	fields  [5]uint64 // Bit n set when value n is allowed
+	anyDom  bool      // Day of month starts with "*"
+	anyDow  bool      // Day of week starts with "*"
+	literal string
+}
+
+// parseCron parses a cron expression such as "30 2 * * sat,sun" or "@daily"
+// Fields accept "*", values, names, ranges ("1-5"), lists ("1,15") and steps ("*/15", "0-30/10")
+func parseCron(spec string) (*cronSchedule, error) {
+	expr := strings.TrimSpace(spec)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+
+	parts := strings.Fields(expr)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("schedule %q must have 5 fields", spec)
+	}
+
+	schedule := &cronSchedule{literal: spec}
+	for i, part := range parts {
+		bits, err := cronFields[i].parse(part)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q: %w", spec, err)
+		}
+		schedule.fields[i] = bits
+	}
+
+	// Sunday may be written as 7
+	if schedule.fields[4]&(1<<7) != 0 {
+		schedule.fields[4] |= 1
+	}
+	schedule.anyDom = strings.HasPrefix(parts[2], "*")
+	schedule.anyDow = strings.HasPrefix(parts[4], "*")
+
+	return schedule, nil
+}
+
+// parse turns one field into a bit set
+func (f cronField) parse(field string) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q in %s", stepPart, f.name)
+			}
+			step = n
+		}
+
+		lo, hi := f.min, f.max
+		if rangePart != "*" {
+			from, to, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if lo, err = f.value(from); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if isRange {
+				if hi, err = f.value(to); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				hi = f.max
+			}
+			if hi < lo {
+				return 0, fmt.Errorf("invalid range %q in %s", rangePart, f.name)
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// value parses a number or name within the field's range
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, nil
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("invalid %s %q", f.name, s)
+	}
+	return n, nil
+}
+
+// matches reports whether the minute starting at t is scheduled
+func (c *cronSchedule) matches(t time.Time) bool {
+	return c.fields[0]&(1<<t.Minute()) != 0 &&
+		c.fields[1]&(1<<t.Hour()) != 0 &&
+		c.dayMatches(t)
+}
+
+// last returns the latest scheduled minute after the given time and at or before t
+// Days and hours without a scheduled minute are skipped whole, so looking back
+// over a long window takes a few steps per day rather than one per minute
+func (c *cronSchedule) last(t, after time.Time) (time.Time, bool) {
+	t = t.Truncate(time.Minute)
+	for t.After(after) {
+		var prev time.Time
+		y, m, d := t.Date()
+		switch {
+		case !c.dayMatches(t):
+			prev = time.Date(y, m, d, 0, 0, 0, 0, t.Location()).Add(-time.Minute)
+		case c.fields[1]&(1<<t.Hour()) == 0:
+			prev = time.Date(y, m, d, t.Hour(), 0, 0, 0, t.Location()).Add(-time.Minute)
+		default:
+			minutes := c.fields[0] & (1<<(t.Minute()+1) - 1)
+			if minutes == 0 {
+				prev = time.Date(y, m, d, t.Hour(), 0, 0, 0, t.Location()).Add(-time.Minute)
+				break
+			}
+			start := t.Add(-time.Duration(t.Minute()-(bits.Len64(minutes)-1)) * time.Minute)
+			if !start.After(after) {
+				return time.Time{}, false
+			}
+			if c.matches(start) {
+				return start, true
+			}
+			prev = t.Add(-time.Minute)
+		}
+
+		// Wall clock arithmetic can stall around daylight saving changes
+		if !prev.Before(t) {
+			prev = t.Add(-time.Minute)
+		}
+		t = prev
+	}
+	return time.Time{}, false
+}
+
+// dayMatches reports whether the day of t is scheduled
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	if c.fields[3]&(1<<int(t.Month())) == 0 {
+		return false
+	}
+
+	dom := c.fields[2]&(1<<t.Day()) != 0
+	dow := c.fields[4]&(1<<int(t.Weekday())) != 0
+	switch {
+	case c.anyDom && c.anyDow:
+		return true
+	case c.anyDom:
+		return dow
+	case c.anyDow:
+		return dom
+	}
+	return dom || dow
+}
+
+// String returns the expression as written
+func (c *cronSchedule) String() string {
+	return c.literal
+}
@@ -0,0 +1,135 @@
+package alerting
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/LissaiDev/Delphos/pkg/echo"
+)
+
+// Comparison operators accepted in rules
+const (
+	OpGreater      = ">"
+	OpGreaterEqual = ">="
+	OpLess         = "<"
+	OpLessEqual    = "<="
+	OpEqual        = "=="
+	OpNotEqual     = "!="
+)
+
+// Alert severities
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// Alert states
+const (
+	StatePending  = "pending"           // Condition met, waiting for the rule's for duration
+	StateFiring   = echo.StatusFiring   // Condition held for the whole for duration
+	StateResolved = echo.StatusResolved // Condition cleared after firing
+)
+
+// Duration is a time.Duration read from JSON as "5m" or a number of seconds
+type Duration time.Duration
+
+// UnmarshalJSON accepts a Go duration string or a number of seconds
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		parsed, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+		*d = Duration(parsed)
+		return nil
+	}
+
+	secs, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return fmt.Errorf("invalid duration %s", data)
+	}
+	*d = Duration(secs * float64(time.Second))
+	return nil
+}
+
+// MarshalJSON renders the duration as a Go duration string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// Rule describes a condition over a metric that raises an alert
+// Every label set of the metric matching Labels is tracked independently
+type Rule struct {
+	Name      string            `json:"name"`      // Unique rule name
+	Metric    string            `json:"metric"`    // Metric name (e.g. "disk.used_percent")
+	Labels    map[string]string `json:"labels"`    // Optional label values the series must have
+	Op        string            `json:"op"`        // Comparison against Threshold
+	Threshold float64           `json:"threshold"` // Value that triggers the alert
+	Clear     *float64          `json:"clear"`     // Value the metric must cross back over to resolve (defaults to Threshold)
+	For       Duration          `json:"for"`       // How long the condition must hold before firing
+	Severity  string            `json:"severity"`  // "info", "warning" or "critical"
+	Processes string            `json:"processes"` // Top processes to list in notifications ("cpu", "memory" or empty)
+}
+
+// File is the layout of the alerting configuration file
+// Omitting rules keeps the default rules; the route tree is optional and
+// sends every alert to every handler when absent
+type File struct {
+	Rules       []*Rule       `json:"rules"`       // Alert rules
+	Route       *Route        `json:"route"`       // Root of the notification routing tree
+	Maintenance []*Window     `json:"maintenance"` // Recurring maintenance windows
+	Escalations []*Escalation `json:"escalations"` // Policies for alerts left unacknowledged
+}
+
+// Files in the data directory
+const (
+	silencesFileName = "silences.json" // Silences created through the API
+	alertLogFileName = "alerts.jsonl"  // Alert state changes, one JSON event per line
+)
+
+// Alerting errors
+var (
+	ErrInvalidRule     = errors.New("invalid alert rule")
+	ErrDuplicateRule   = errors.New("duplicate alert rule name")
+	ErrInvalidRoute    = errors.New("invalid notification route")
+	ErrInvalidWindow   = errors.New("invalid maintenance window")
+	ErrInvalidSilence  = errors.New("invalid silence")
+	ErrSilenceNotFound = errors.New("silence not found")
+
+	ErrInvalidEscalation = errors.New("invalid escalation policy")
+	ErrInvalidAck        = errors.New("invalid acknowledgement")
+	ErrAlertNotFound     = errors.New("alert not found")
+	ErrAlertNotFiring    = errors.New("alert is not firing")
+)
+
+// State is the tracked condition of one rule and label set
+type State struct {
+	Rule         string            `json:"rule"`                 // Rule name
+	Fingerprint  string            `json:"fingerprint"`          // Stable identifier of rule and labels
+	Metric       string            `json:"metric"`               // Evaluated metric
+	Labels       map[string]string `json:"labels"`               // Labels of the evaluated series
+	Severity     string            `json:"severity"`             // Rule severity
+	Status       string            `json:"status"`               // "pending", "firing" or "resolved"
+	Value        float64           `json:"value"`                // Latest evaluated value
+	Peak         float64           `json:"peak"`                 // Worst value observed since the condition was met
+	Threshold    float64           `json:"threshold"`            // Rule threshold
+	ActiveAt     time.Time         `json:"activeAt"`             // When the condition was first met
+	FiredAt      time.Time         `json:"firedAt,omitzero"`     // When the alert started firing
+	ResolvedAt   time.Time         `json:"resolvedAt,omitzero"`  // When the alert resolved
+	SilencedBy   []string          `json:"silencedBy,omitempty"` // Silences and maintenance windows muting the alert
+	AckedBy      string            `json:"ackedBy,omitempty"`    // Who acknowledged the alert
+	AckNote      string            `json:"ackNote,omitempty"`    // Note left with the acknowledgement
+	AckedAt      time.Time         `json:"ackedAt,omitzero"`     // When the alert was acknowledged
+	Escalated    []string          `json:"escalated,omitempty"`  // Escalation policies that notified their handlers
+	LastNotified time.Time         `json:"-"`                    // When a notification was last sent
+}
+
+// Acknowledged reports whether someone took the alert on
+func (st *State) Acknowledged() bool {
+	return !st.AckedAt.IsZero()
+}
@@ -0,0 +1,719 @@
+package alerting
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LissaiDev/Delphos/internal/config"
+	"github.com/LissaiDev/Delphos/internal/monitor"
+	"github.com/LissaiDev/Delphos/pkg/echo"
+	"github.com/LissaiDev/Delphos/pkg/logger"
+)
+
+// Engine evaluates alert rules against every stats sample
+// Each rule and label set moves independently through pending, firing and
+// resolved, and firing alerts are re-notified every repeat interval until they
+// are acknowledged. State changes are recorded in the alert log and passed to
+// subscribers.
+type Engine struct {
+	logger    logger.BasicLogger
+	notifier  echo.Notifier
+	rules     []*Rule
+	repeat    time.Duration
+	silences  *Silences         // Silences and maintenance windows, nil when none apply
+	alertLog  *AlertLog         // History of state changes, nil when not kept
+	escalator echo.Dispatcher   // Delivers escalations, nil when no policy is set
+	policies  []*Escalation     // Escalation policies, tried in order
+	host      string            // Hostname reported with notifications
+	states    map[string]*State // Fingerprint -> state
+	events    []Event           // Changes of the running evaluation, published once it is done
+	outbox    []notification    // Notifications of the running evaluation, sent once it is done
+	listeners []func(Event)
+	mu        sync.Mutex
+}
+
+// notification is an alert waiting to be sent once the engine lock is released
+type notification struct {
+	alert     *echo.Alert
+	policy    *Escalation // Escalation policy whose handlers get the alert, nil for the notifier
+	processes string      // Ordering of the top processes added to the details, empty for none
+}
+
+var (
+	engineInstance *Engine
+	once           sync.Once
+)
+
+// New creates an alert engine
+// repeat is how often a firing alert is notified again (0 notifies once); silences and alertLog may be nil
+func New(log logger.BasicLogger, notifier echo.Notifier, rules []*Rule, repeat time.Duration, silences *Silences, alertLog *AlertLog) *Engine {
+	host, _ := os.Hostname()
+	return &Engine{
+		logger:   log,
+		notifier: notifier,
+		rules:    rules,
+		repeat:   repeat,
+		silences: silences,
+		alertLog: alertLog,
+		host:     host,
+		states:   make(map[string]*State),
+	}
+}
+
+// GetInstance returns the shared engine
+// Rules come from config.Env.AlertingFile when set, and mirror the threshold settings otherwise.
+// A routing tree in the file puts a Router between the engine and the handlers.
+func GetInstance() *Engine {
+	once.Do(func() {
+		log := logger.GetInstance()
+
+		rules := DefaultRules()
+		var (
+			route       *Route
+			windows     []*Window
+			escalations []*Escalation
+		)
+		if path := config.Env.AlertingFile; path != "" {
+			file, err := LoadFile(path)
+			if err != nil {
+				log.Error("Failed to load alerting file, using default rules", map[string]interface{}{
+					"path":  path,
+					"error": err.Error(),
+				})
+			} else {
+				if file.Rules != nil {
+					rules = file.Rules
+				}
+				route = file.Route
+				windows = file.Maintenance
+				escalations = file.Escalations
+			}
+		}
+
+		log.Info("Alert rules loaded", map[string]interface{}{
+			"rules":       len(rules),
+			"routing":     route != nil,
+			"maintenance": len(windows),
+			"escalations": len(escalations),
+		})
+
+		silencesPath, alertLogPath := "", ""
+		if config.Env.DataDir != "" {
+			silencesPath = filepath.Join(config.Env.DataDir, silencesFileName)
+			alertLogPath = filepath.Join(config.Env.DataDir, alertLogFileName)
+		}
+		silences := NewSilences(log, silencesPath, windows)
+
+		retention := time.Duration(config.Env.AlertLogRetention) * time.Second
+		alertLog, err := OpenAlertLog(log, alertLogPath, retention)
+		if err != nil {
+			log.Error("Failed to open alert log, keeping it in memory", map[string]interface{}{
+				"path":  alertLogPath,
+				"error": err.Error(),
+			})
+			alertLog, _ = OpenAlertLog(log, "", retention)
+		}
+
+		notifier := echo.GetInstance()
+		dispatcher, _ := notifier.(echo.Dispatcher)
+		repeat := time.Duration(config.Env.Cooldown) * time.Second
+		if dispatcher != nil && route != nil {
+			router := NewRouter(log, dispatcher, route, repeat)
+			notifier = router
+			repeat = router.Interval()
+		}
+
+		engineInstance = New(log, notifier, rules, repeat, silences, alertLog)
+		if len(escalations) > 0 {
+			if dispatcher != nil {
+				engineInstance.SetEscalations(dispatcher, escalations)
+			} else {
+				log.Error("Escalation policies need handlers that can be addressed by name, ignoring them", map[string]interface{}{
+					"escalations": len(escalations),
+				})
+			}
+		}
+	})
+	return engineInstance
+}
+
+// Enabled reports whether any rule is configured
+func (e *Engine) Enabled() bool {
+	return len(e.rules) > 0
+}
+
+// Silences returns the silence store
+func (e *Engine) Silences() *Silences {
+	return e.silences
+}
+
+// SetEscalations installs escalation policies delivered through dispatcher
+// It is meant to be called before the first evaluation
+func (e *Engine) SetEscalations(dispatcher echo.Dispatcher, policies []*Escalation) {
+	known := dispatcher.HandlerNames()
+	for _, policy := range policies {
+		for _, name := range policy.Handlers {
+			if !slices.Contains(known, name) {
+				e.logger.Warn("Escalation policy names a handler that is not configured", map[string]interface{}{
+					"escalation": policy.Name,
+					"handler":    name,
+					"configured": known,
+				})
+			}
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.escalator = dispatcher
+	e.policies = policies
+}
+
+// Acknowledge records that someone is handling a firing alert
+// Handlers are notified of the acknowledgement unless the alert is silenced.
+// Acknowledged alerts are not notified again and do not escalate; their
+// resolution is still notified
+func (e *Engine) Acknowledge(fingerprint, by, note string, at time.Time) (*State, error) {
+	if fingerprint == "" || by == "" {
+		return nil, fmt.Errorf("%w: fingerprint and by are required", ErrInvalidAck)
+	}
+	return e.changeAck(fingerprint, EventAcknowledged, at, func(st *State) {
+		st.AckedBy = by
+		st.AckNote = note
+		st.AckedAt = at
+		e.logger.Info("Alert acknowledged", map[string]interface{}{
+			"rule":     st.Rule,
+			"labels":   st.Labels,
+			"acked_by": by,
+			"note":     note,
+		})
+	})
+}
+
+// Unacknowledge withdraws the acknowledgement of a firing alert, so it is
+// notified right away and repeated and escalated again
+func (e *Engine) Unacknowledge(fingerprint string, at time.Time) (*State, error) {
+	return e.changeAck(fingerprint, EventUnacknowledged, at, func(st *State) {
+		e.logger.Info("Alert acknowledgement withdrawn", map[string]interface{}{
+			"rule":     st.Rule,
+			"labels":   st.Labels,
+			"acked_by": st.AckedBy,
+		})
+		st.AckedBy = ""
+		st.AckNote = ""
+		st.AckedAt = time.Time{}
+	})
+}
+
+// changeAck applies an acknowledgement change to a firing alert and publishes it
+func (e *Engine) changeAck(fingerprint, status string, at time.Time, change func(st *State)) (*State, error) {
+	e.mu.Lock()
+	st := e.states[fingerprint]
+	if st == nil {
+		e.mu.Unlock()
+		return nil, ErrAlertNotFound
+	}
+	if st.Status != StateFiring {
+		e.mu.Unlock()
+		return nil, ErrAlertNotFiring
+	}
+
+	change(st)
+	e.record(st, status, at)
+	if rule := e.rule(st.Rule); rule != nil {
+		e.notifyAck(rule, st, status, at)
+	}
+	result := *st
+	events, listeners, outbox := e.events, e.listeners, e.outbox
+	e.events, e.outbox = nil, nil
+	e.mu.Unlock()
+
+	e.publish(events, listeners)
+	e.deliver(outbox, nil)
+	return &result, nil
+}
+
+// notifyAck queues the notification of an acknowledgement change; callers hold the lock
+// An acknowledgement is sent with the acknowledged status, so paging services
+// acknowledge their incident, and a withdrawal notifies the alert as firing again
+func (e *Engine) notifyAck(rule *Rule, st *State, status string, at time.Time) {
+	// Nobody heard of an alert that stayed silenced while it fired
+	if st.LastNotified.IsZero() {
+		return
+	}
+
+	alert := e.alert(rule, st)
+	if e.muted(alert, at) {
+		return
+	}
+	if status == EventAcknowledged {
+		alert.Status = echo.StatusAcknowledged
+		alert.Details = "Acknowledged by " + st.AckedBy
+		if st.AckNote != "" {
+			alert.Details += ": " + st.AckNote
+		}
+	} else {
+		alert.Details = "Acknowledgement withdrawn"
+		st.LastNotified = at
+	}
+
+	for _, policy := range e.policies {
+		if slices.Contains(st.Escalated, policy.Name) {
+			e.outbox = append(e.outbox, notification{alert: alert, policy: policy})
+		}
+	}
+	e.outbox = append(e.outbox, notification{alert: alert})
+}
+
+// rule returns the rule with the given name, nil when there is none; callers hold the lock
+func (e *Engine) rule(name string) *Rule {
+	for _, rule := range e.rules {
+		if rule.Name == name {
+			return rule
+		}
+	}
+	return nil
+}
+
+// AlertLog returns the log of alert state changes, nil when none is kept
+func (e *Engine) AlertLog() *AlertLog {
+	return e.alertLog
+}
+
+// Subscribe registers fn to receive every alert state change
+// fn is called after each evaluation, outside the engine lock
+func (e *Engine) Subscribe(fn func(Event)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.listeners = append(e.listeners, fn)
+}
+
+// Rules returns the configured rules
+func (e *Engine) Rules() []*Rule {
+	return e.rules
+}
+
+// States returns a copy of the tracked alert states, ordered by rule and fingerprint
+func (e *Engine) States() []State {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	states := make([]State, 0, len(e.states))
+	for _, st := range e.states {
+		states = append(states, *st)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		if states[i].Rule != states[j].Rule {
+			return states[i].Rule < states[j].Rule
+		}
+		return states[i].Fingerprint < states[j].Fingerprint
+	})
+	return states
+}
+
+// Evaluate runs every rule against the samples taken at the given time
+// samples holds the samples of stats along with derived ones (e.g. forecasts);
+// stats supplies the host name and top processes for notifications
+func (e *Engine) Evaluate(at time.Time, stats *monitor.Monitor, samples []monitor.Sample) {
+	byMetric := make(map[string][]monitor.Sample)
+	for _, sample := range samples {
+		byMetric[sample.Name] = append(byMetric[sample.Name], sample)
+	}
+
+	e.mu.Lock()
+	e.evaluate(at, byMetric, stats)
+	events, listeners, outbox := e.events, e.listeners, e.outbox
+	e.events, e.outbox = nil, nil
+	e.mu.Unlock()
+
+	// Process scans and handlers can be slow, so they run outside the lock
+	e.publish(events, listeners)
+	e.deliver(outbox, stats)
+}
+
+// evaluate runs every rule against the samples grouped by metric; callers hold the lock
+func (e *Engine) evaluate(at time.Time, byMetric map[string][]monitor.Sample, stats *monitor.Monitor) {
+	if stats.Host != nil && stats.Host.Hostname != "" {
+		e.host = stats.Host.Hostname
+	}
+
+	for _, rule := range e.rules {
+		samples := byMetric[rule.Metric]
+		seen := make(map[string]bool)
+
+		for _, sample := range samples {
+			if !matches(sample.Labels, rule.Labels) {
+				continue
+			}
+			fingerprint := Fingerprint(rule.Name, sample.Labels)
+			seen[fingerprint] = true
+			e.step(rule, fingerprint, sample, at)
+		}
+
+		// A metric without any sample means its collector failed; keep the
+		// state until it reports again. Otherwise series missing from the
+		// sample have disappeared (e.g. an unmounted filesystem).
+		if len(samples) == 0 {
+			continue
+		}
+		for fingerprint, st := range e.states {
+			if st.Rule != rule.Name || seen[fingerprint] {
+				continue
+			}
+			switch st.Status {
+			case StatePending:
+				e.record(st, EventInactive, at)
+				delete(e.states, fingerprint)
+			case StateFiring:
+				e.resolve(rule, st, at)
+			}
+		}
+	}
+}
+
+// step advances the state of one rule and label set; callers hold the lock
+func (e *Engine) step(rule *Rule, fingerprint string, sample monitor.Sample, at time.Time) {
+	st := e.states[fingerprint]
+
+	if st == nil {
+		if !rule.triggered(sample.Value) {
+			return
+		}
+		st = &State{
+			Rule:        rule.Name,
+			Fingerprint: fingerprint,
+			Metric:      rule.Metric,
+			Labels:      sample.Labels,
+			Severity:    rule.Severity,
+			Status:      StatePending,
+			Value:       sample.Value,
+			Peak:        sample.Value,
+			Threshold:   rule.Threshold,
+			ActiveAt:    at,
+		}
+		e.states[fingerprint] = st
+		e.record(st, StatePending, at)
+		e.logger.Debug("Alert pending", map[string]interface{}{
+			"rule":   rule.Name,
+			"labels": sample.Labels,
+			"value":  sample.Value,
+		})
+	}
+
+	st.Value = sample.Value
+	if rule.worse(sample.Value, st.Peak) {
+		st.Peak = sample.Value
+	}
+
+	switch st.Status {
+	case StatePending:
+		if !rule.triggered(sample.Value) {
+			e.record(st, EventInactive, at)
+			delete(e.states, fingerprint)
+			e.logger.Debug("Pending alert cleared before firing", map[string]interface{}{
+				"rule":   rule.Name,
+				"labels": sample.Labels,
+				"value":  sample.Value,
+			})
+			return
+		}
+		if at.Sub(st.ActiveAt) >= time.Duration(rule.For) {
+			st.Status = StateFiring
+			st.FiredAt = at
+			e.logger.Warn("Alert firing", map[string]interface{}{
+				"rule":     rule.Name,
+				"severity": rule.Severity,
+				"labels":   sample.Labels,
+				"value":    sample.Value,
+			})
+			e.notifyFiring(rule, st, at)
+			e.record(st, StateFiring, at)
+		}
+
+	case StateFiring:
+		if !rule.holds(sample.Value) {
+			e.resolve(rule, st, at)
+			return
+		}
+		if st.Acknowledged() {
+			return
+		}
+		// An alert silenced when it fired is notified once the silence ends
+		if st.LastNotified.IsZero() || (e.repeat > 0 && at.Sub(st.LastNotified) >= e.repeat) {
+			e.notifyFiring(rule, st, at)
+		}
+		e.escalate(rule, st, at)
+	}
+}
+
+// resolve marks a firing alert as resolved, notifies and stops tracking it; callers hold the lock
+// The alert log keeps the resolution, and a new firing starts from a fresh state
+func (e *Engine) resolve(rule *Rule, st *State, at time.Time) {
+	st.Status = StateResolved
+	st.ResolvedAt = at
+	e.record(st, StateResolved, at)
+	delete(e.states, st.Fingerprint)
+
+	alert := e.alert(rule, st)
+	e.logger.Info("Alert resolved", map[string]interface{}{
+		"rule":     st.Rule,
+		"labels":   st.Labels,
+		"value":    st.Value,
+		"peak":     st.Peak,
+		"duration": alert.Duration().String(),
+	})
+
+	// Silences and maintenance windows mute the resolution as well
+	if e.muted(alert, at) {
+		return
+	}
+
+	// Handlers an alert escalated to hear about its resolution too
+	for _, policy := range e.policies {
+		if slices.Contains(st.Escalated, policy.Name) {
+			e.outbox = append(e.outbox, notification{alert: alert, policy: policy})
+		}
+	}
+
+	// Nobody heard of an alert that stayed silenced while it fired
+	if st.LastNotified.IsZero() {
+		return
+	}
+	e.outbox = append(e.outbox, notification{alert: alert})
+}
+
+// escalate notifies the handlers of every due policy an unacknowledged alert
+// has not been escalated to yet; callers hold the lock
+func (e *Engine) escalate(rule *Rule, st *State, at time.Time) {
+	if len(e.policies) == 0 {
+		return
+	}
+
+	alert := e.alert(rule, st)
+	if e.muted(alert, at) {
+		return
+	}
+
+	for _, policy := range e.policies {
+		if slices.Contains(st.Escalated, policy.Name) || !policy.due(st, at) || !policy.matches(alert) {
+			continue
+		}
+		st.Escalated = append(st.Escalated, policy.Name)
+
+		e.logger.Warn("Alert escalated", map[string]interface{}{
+			"rule":       st.Rule,
+			"labels":     st.Labels,
+			"escalation": policy.Name,
+			"handlers":   policy.Handlers,
+		})
+		event := e.event(st, EventEscalated, at)
+		event.Escalation = policy.Name
+		e.events = append(e.events, event)
+
+		escalated := *alert
+		escalated.Details = fmt.Sprintf("Escalated by %s: not acknowledged within %s", policy.Name, time.Duration(policy.After))
+		e.outbox = append(e.outbox, notification{alert: &escalated, policy: policy})
+	}
+}
+
+// dispatch hands an alert to the handlers of an escalation policy, logging failures
+func (e *Engine) dispatch(policy *Escalation, alert *echo.Alert) {
+	if err := e.escalator.Dispatch(alert, policy.Handlers); err != nil {
+		e.logger.Error("Failed to send escalated alert", map[string]interface{}{
+			"rule":       alert.Rule,
+			"escalation": policy.Name,
+			"error":      err.Error(),
+		})
+	}
+}
+
+// notifyFiring queues a firing notification for a state; callers hold the lock
+// Silenced alerts are recorded on the state instead
+func (e *Engine) notifyFiring(rule *Rule, st *State, at time.Time) {
+	alert := e.alert(rule, st)
+
+	if e.silenced(st, alert, at) {
+		return
+	}
+	st.LastNotified = at
+
+	e.outbox = append(e.outbox, notification{alert: alert, processes: rule.Processes})
+}
+
+// alert builds the notification for a state; callers hold the lock
+func (e *Engine) alert(rule *Rule, st *State) *echo.Alert {
+	return &echo.Alert{
+		Rule:        rule.Name,
+		Severity:    rule.Severity,
+		Status:      st.Status,
+		Metric:      rule.Metric,
+		Op:          rule.Op,
+		Value:       st.Value,
+		Threshold:   rule.Threshold,
+		Peak:        st.Peak,
+		Labels:      st.Labels,
+		Host:        e.host,
+		StartsAt:    st.ActiveAt,
+		EndsAt:      st.ResolvedAt,
+		Fingerprint: st.Fingerprint,
+	}
+}
+
+// silenced records on the state whether a silence or maintenance window mutes
+// the alert, logging changes; callers hold the lock
+func (e *Engine) silenced(st *State, alert *echo.Alert, at time.Time) bool {
+	var reasons []string
+	if e.silences != nil {
+		reasons = e.silences.Match(alert, at)
+	}
+
+	if len(reasons) > 0 && !slices.Equal(reasons, st.SilencedBy) {
+		e.logger.Info("Alert silenced", map[string]interface{}{
+			"rule":        alert.Rule,
+			"labels":      alert.Labels,
+			"silenced_by": reasons,
+		})
+	}
+	if len(reasons) == 0 && len(st.SilencedBy) > 0 {
+		e.logger.Info("Alert no longer silenced", map[string]interface{}{
+			"rule":   alert.Rule,
+			"labels": alert.Labels,
+		})
+	}
+
+	st.SilencedBy = reasons
+	return len(reasons) > 0
+}
+
+// muted reports whether a silence or maintenance window applies to the alert at the given time
+// Unlike silenced it leaves the state alone, for notifications other than firing ones
+func (e *Engine) muted(alert *echo.Alert, at time.Time) bool {
+	return e.silences != nil && len(e.silences.Match(alert, at)) > 0
+}
+
+// record queues a state change for the alert log and subscribers; callers hold the lock
+func (e *Engine) record(st *State, status string, at time.Time) {
+	e.events = append(e.events, e.event(st, status, at))
+}
+
+// event describes a change of a state; callers hold the lock
+func (e *Engine) event(st *State, status string, at time.Time) Event {
+	return Event{
+		Time:        at,
+		Rule:        st.Rule,
+		Fingerprint: st.Fingerprint,
+		Status:      status,
+		Severity:    st.Severity,
+		Metric:      st.Metric,
+		Labels:      st.Labels,
+		Host:        e.host,
+		Value:       st.Value,
+		Peak:        st.Peak,
+		Threshold:   st.Threshold,
+		ActiveAt:    st.ActiveAt,
+		SilencedBy:  st.SilencedBy,
+		AckedBy:     st.AckedBy,
+		AckNote:     st.AckNote,
+	}
+}
+
+// publish appends the changes of an evaluation to the alert log and hands them to subscribers
+func (e *Engine) publish(events []Event, listeners []func(Event)) {
+	for _, event := range events {
+		if e.alertLog != nil {
+			e.alertLog.Append(event)
+		}
+		for _, listener := range listeners {
+			listener(event)
+		}
+	}
+}
+
+// deliver sends the notifications queued by an evaluation or acknowledgement
+// stats supplies the top processes and may be nil when no notification lists them
+func (e *Engine) deliver(outbox []notification, stats *monitor.Monitor) {
+	for _, n := range outbox {
+		if n.processes != "" && stats != nil {
+			n.alert.Details = e.topProcessesSummary(stats, n.processes, config.Env.AlertProcesses)
+		}
+		if n.policy != nil {
+			e.dispatch(n.policy, n.alert)
+		} else {
+			e.send(n.alert)
+		}
+	}
+}
+
+// send hands an alert to the notifier, logging failures
+func (e *Engine) send(alert *echo.Alert) {
+	if err := e.notifier.Notify(alert); err != nil {
+		e.logger.Error("Failed to send alert notification", map[string]interface{}{
+			"rule":  alert.Rule,
+			"error": err.Error(),
+		})
+	}
+}
+
+// topProcessesSummary renders the top offenders for an alert message.
+// CPU offenders are taken from the current sample; other orderings trigger a fresh collection.
+func (e *Engine) topProcessesSummary(stats *monitor.Monitor, sortBy string, count int) string {
+	if count <= 0 {
+		return ""
+	}
+
+	procs := stats.Processes
+	if sortBy != monitor.ProcessSortCPU || len(procs) < count {
+		collected, err := monitor.GetProcessInfo(count, sortBy)
+		if err != nil {
+			e.logger.Error("Failed to collect top processes for alert", map[string]interface{}{
+				"sort_by": sortBy,
+				"error":   err.Error(),
+			})
+			return ""
+		}
+		procs = collected
+	}
+	if len(procs) > count {
+		procs = procs[:count]
+	}
+	if len(procs) == 0 {
+		return ""
+	}
+
+	parts := make([]string, 0, len(procs))
+	for _, p := range procs {
+		if sortBy == monitor.ProcessSortMemory {
+			parts = append(parts, fmt.Sprintf("%s (pid %d, %.1f MB)", p.Name, p.PID, float64(p.RSS)/1024/1024))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s (pid %d, %.1f%%)", p.Name, p.PID, p.CPUPercent))
+		}
+	}
+
+	return "Top processes: " + strings.Join(parts, ", ")
+}
+
+// Fingerprint identifies a rule and label set
+func Fingerprint(rule string, labels map[string]string) string {
+	sum := sha256.Sum256([]byte(monitor.SeriesKey(rule, labels)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// matches reports whether labels carry every matcher value
+func matches(labels, matchers map[string]string) bool {
+	for k, v := range matchers {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
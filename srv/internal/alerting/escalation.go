@@ -0,0 +1,63 @@
+package alerting
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/LissaiDev/Delphos/pkg/echo"
+)
+
+// Escalation notifies extra handlers about an alert nobody acknowledged in time
+// A firing alert matching the policy that is still unacknowledged After it
+// fired is sent once to Handlers; its resolution is sent to them as well.
+// Several policies with growing After values form escalation levels.
+type Escalation struct {
+	Name     string            `json:"name"`     // Policy name, reported on the alert
+	Severity []string          `json:"severity"` // Severities matched (any of them, empty matches all)
+	Rules    []string          `json:"rules"`    // Rule names matched (any of them, empty matches all)
+	Hosts    []string          `json:"hosts"`    // Hosts matched (any of them, empty matches all)
+	Labels   map[string]string `json:"labels"`   // Label values the alert must have
+	After    Duration          `json:"after"`    // How long an alert may stay unacknowledged
+	Handlers []string          `json:"handlers"` // Handlers notified on escalation (e.g. "pagerduty")
+}
+
+// Validate checks the policy
+func (p *Escalation) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidEscalation)
+	}
+	if p.After <= 0 {
+		return fmt.Errorf("%w: %s: after must be positive", ErrInvalidEscalation, p.Name)
+	}
+	if len(p.Handlers) == 0 {
+		return fmt.Errorf("%w: %s: at least one handler is required", ErrInvalidEscalation, p.Name)
+	}
+	for _, severity := range p.Severity {
+		switch severity {
+		case SeverityInfo, SeverityWarning, SeverityCritical:
+		default:
+			return fmt.Errorf("%w: %s: unknown severity %q", ErrInvalidEscalation, p.Name, severity)
+		}
+	}
+	return nil
+}
+
+// matches reports whether an alert falls under the policy
+func (p *Escalation) matches(alert *echo.Alert) bool {
+	if len(p.Severity) > 0 && !slices.Contains(p.Severity, alert.Severity) {
+		return false
+	}
+	if len(p.Rules) > 0 && !slices.Contains(p.Rules, alert.Rule) {
+		return false
+	}
+	if len(p.Hosts) > 0 && !slices.Contains(p.Hosts, alert.Host) {
+		return false
+	}
+	return matches(alert.Labels, p.Labels)
+}
+
+// due reports whether a firing alert has waited long enough for the policy
+func (p *Escalation) due(st *State, at time.Time) bool {
+	return at.Sub(st.FiredAt) >= time.Duration(p.After)
+}
@@ -0,0 +1,400 @@
+package alerting
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LissaiDev/Delphos/pkg/echo"
+	"github.com/LissaiDev/Delphos/pkg/logger"
+)
+
+// Fields other than labels that routes can group by
+const (
+	GroupByRule     = "rule"
+	GroupBySeverity = "severity"
+	GroupByHost     = "host"
+)
+
+// Route is a node of the notification routing tree
+// An alert descends into the first child route matching it, or into every
+// matching child up to and including the first one without Continue. When no
+// child matches, the route itself picks the handlers. Unset fields are
+// inherited from the parent route.
+type Route struct {
+	Name           string            `json:"name"`           // Optional name used in logs
+	Severity       []string          `json:"severity"`       // Severities matched (any of them)
+	Rules          []string          `json:"rules"`          // Rule names matched (any of them)
+	Hosts          []string          `json:"hosts"`          // Hosts matched (any of them)
+	Labels         map[string]string `json:"labels"`         // Label values the alert must have
+	Handlers       []string          `json:"handlers"`       // Handlers notified (e.g. "discord", "pagerduty"); unset inherits the parent's (every handler at the root), [] drops the alert
+	Continue       bool              `json:"continue"`       // Keep matching the following sibling routes
+	GroupBy        []string          `json:"groupBy"`        // Fields ("rule", "severity", "host" or label names) whose values form a group
+	GroupWait      *Duration         `json:"groupWait"`      // How long the first notification of a new group is held
+	GroupInterval  *Duration         `json:"groupInterval"`  // Minimum time between notifications of a group
+	RepeatInterval *Duration         `json:"repeatInterval"` // How often an alert that keeps firing is notified again
+	Routes         []*Route          `json:"routes"`         // Child routes
+
+	id string // Position in the tree, e.g. "root.1.0"
+}
+
+// Validate checks the routing tree below the root route
+// The root matches every alert, so it cannot have matchers
+func (r *Route) Validate() error {
+	if len(r.Severity) > 0 || len(r.Rules) > 0 || len(r.Hosts) > 0 || len(r.Labels) > 0 {
+		return fmt.Errorf("%w: the root route matches every alert and cannot have matchers", ErrInvalidRoute)
+	}
+	return r.validate("root")
+}
+
+// validate checks a route and its children
+func (r *Route) validate(path string) error {
+	name := path
+	if r.Name != "" {
+		name = r.Name
+	}
+
+	for _, severity := range r.Severity {
+		switch severity {
+		case SeverityInfo, SeverityWarning, SeverityCritical:
+		default:
+			return fmt.Errorf("%w: %s: unknown severity %q", ErrInvalidRoute, name, severity)
+		}
+	}
+	for _, d := range []*Duration{r.GroupWait, r.GroupInterval, r.RepeatInterval} {
+		if d != nil && *d < 0 {
+			return fmt.Errorf("%w: %s: durations must not be negative", ErrInvalidRoute, name)
+		}
+	}
+
+	for i, child := range r.Routes {
+		if err := child.validate(path + "." + strconv.Itoa(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inherit fills unset fields from the parent and numbers the routes; parent is nil for the root
+func (r *Route) inherit(parent *Route, id string) {
+	r.id = id
+	if parent != nil {
+		if r.Handlers == nil {
+			r.Handlers = parent.Handlers
+		}
+		if r.GroupBy == nil {
+			r.GroupBy = parent.GroupBy
+		}
+		if r.GroupWait == nil {
+			r.GroupWait = parent.GroupWait
+		}
+		if r.GroupInterval == nil {
+			r.GroupInterval = parent.GroupInterval
+		}
+		if r.RepeatInterval == nil {
+			r.RepeatInterval = parent.RepeatInterval
+		}
+	}
+
+	for i, child := range r.Routes {
+		child.inherit(r, id+"."+strconv.Itoa(i))
+	}
+}
+
+// label names the route in logs
+func (r *Route) label() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.id
+}
+
+// matches reports whether an alert satisfies the route's own matchers
+func (r *Route) matches(alert *echo.Alert) bool {
+	if len(r.Severity) > 0 && !slices.Contains(r.Severity, alert.Severity) {
+		return false
+	}
+	if len(r.Rules) > 0 && !slices.Contains(r.Rules, alert.Rule) {
+		return false
+	}
+	if len(r.Hosts) > 0 && !slices.Contains(r.Hosts, alert.Host) {
+		return false
+	}
+	return matches(alert.Labels, r.Labels)
+}
+
+// route returns the routes that handle an alert already matching r
+func (r *Route) route(alert *echo.Alert) []*Route {
+	var routes []*Route
+	for _, child := range r.Routes {
+		if !child.matches(alert) {
+			continue
+		}
+		routes = append(routes, child.route(alert)...)
+		if !child.Continue {
+			break
+		}
+	}
+	if len(routes) == 0 {
+		return []*Route{r}
+	}
+	return routes
+}
+
+// groupKey identifies the group of an alert within the route
+func (r *Route) groupKey(alert *echo.Alert) string {
+	parts := make([]string, 0, len(r.GroupBy))
+	for _, field := range r.GroupBy {
+		var value string
+		switch field {
+		case GroupByRule:
+			value = alert.Rule
+		case GroupBySeverity:
+			value = alert.Severity
+		case GroupByHost:
+			value = alert.Host
+		default:
+			value = alert.Labels[field]
+		}
+		parts = append(parts, field+"="+value)
+	}
+	return r.id + "{" + strings.Join(parts, ",") + "}"
+}
+
+// walk calls fn for the route and every route below it
+func (r *Route) walk(fn func(*Route)) {
+	fn(r)
+	for _, child := range r.Routes {
+		child.walk(fn)
+	}
+}
+
+// routeGroup holds the alerts of a group waiting to be sent
+type routeGroup struct {
+	route     *Route
+	alerts    []*echo.Alert
+	timer     *time.Timer // Pending flush, nil when nothing is held
+	lastFlush time.Time
+}
+
+// repeatTolerance absorbs the jitter between the evaluation that sent an alert
+// and the one offering its repeat
+const repeatTolerance = time.Second
+
+// Router is a Notifier that sends alerts through the routing tree
+// Within a route, alerts of a group are held for the group wait when the group
+// is new and for what is left of the group interval otherwise, then sent
+// together as one notification. Repeat notifications of an alert are dropped until the route's
+// repeat interval has passed since it was last sent.
+type Router struct {
+	logger     logger.BasicLogger
+	dispatcher echo.Dispatcher
+	root       *Route
+	groups     map[string]*routeGroup // Group key -> group
+	sent       map[string]time.Time   // Route and fingerprint -> last firing notification
+	mu         sync.Mutex
+}
+
+// NewRouter creates a router over a validated routing tree
+// repeat is the repeat interval of routes that do not set one
+func NewRouter(log logger.BasicLogger, dispatcher echo.Dispatcher, root *Route, repeat time.Duration) *Router {
+	if root.RepeatInterval == nil {
+		d := Duration(repeat)
+		root.RepeatInterval = &d
+	}
+	if root.GroupWait == nil {
+		root.GroupWait = new(Duration)
+	}
+	if root.GroupInterval == nil {
+		root.GroupInterval = new(Duration)
+	}
+	root.inherit(nil, "root")
+
+	known := dispatcher.HandlerNames()
+	root.walk(func(route *Route) {
+		for _, name := range route.Handlers {
+			if !slices.Contains(known, name) {
+				log.Warn("Route names a handler that is not configured", map[string]interface{}{
+					"route":      route.label(),
+					"handler":    name,
+					"configured": known,
+				})
+			}
+		}
+	})
+
+	return &Router{
+		logger:     log,
+		dispatcher: dispatcher,
+		root:       root,
+		groups:     make(map[string]*routeGroup),
+		sent:       make(map[string]time.Time),
+	}
+}
+
+// Interval returns the shortest repeat interval in the tree, 0 when no route repeats
+// The engine has to offer repeats at least this often
+func (r *Router) Interval() time.Duration {
+	var interval time.Duration
+	r.root.walk(func(route *Route) {
+		repeat := time.Duration(*route.RepeatInterval)
+		if repeat > 0 && (interval == 0 || repeat < interval) {
+			interval = repeat
+		}
+	})
+	return interval
+}
+
+// Notify routes an alert to the handlers of every route it ends up in
+func (r *Router) Notify(alert *echo.Alert) error {
+	now := time.Now()
+	routes := r.root.route(alert)
+
+	r.mu.Lock()
+	var ready []*Route
+	for _, route := range routes {
+		key := route.id + "/" + alert.Fingerprint
+		if alert.Status == echo.StatusFiring {
+			repeat := time.Duration(*route.RepeatInterval)
+			if last, ok := r.sent[key]; ok && (repeat == 0 || now.Sub(last) < repeat-repeatTolerance) {
+				continue
+			}
+			r.sent[key] = now
+		} else {
+			delete(r.sent, key)
+		}
+
+		if *route.GroupWait == 0 && *route.GroupInterval == 0 {
+			ready = append(ready, route)
+			continue
+		}
+		r.hold(route, alert, now)
+	}
+	r.mu.Unlock()
+
+	for _, route := range ready {
+		r.dispatch(route, alert)
+	}
+	return nil
+}
+
+// hold adds an alert to its group and schedules the group's flush; callers hold the lock
+func (r *Router) hold(route *Route, alert *echo.Alert, now time.Time) {
+	key := route.groupKey(alert)
+	group := r.groups[key]
+	if group == nil {
+		group = &routeGroup{route: route}
+		r.groups[key] = group
+	}
+
+	// A newer notification of the same alert and status replaces the held one
+	replaced := false
+	for i, held := range group.alerts {
+		if held.Fingerprint == alert.Fingerprint && held.Status == alert.Status {
+			group.alerts[i] = alert
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		group.alerts = append(group.alerts, alert)
+	}
+
+	if group.timer != nil {
+		return
+	}
+
+	wait := time.Duration(*route.GroupWait)
+	interval := time.Duration(*route.GroupInterval)
+	if !group.lastFlush.IsZero() && now.Sub(group.lastFlush) < interval {
+		wait = interval - now.Sub(group.lastFlush)
+	}
+	group.timer = time.AfterFunc(wait, func() {
+		r.flush(key)
+	})
+
+	r.logger.Debug("Alert held for its group", map[string]interface{}{
+		"route": route.label(),
+		"group": key,
+		"rule":  alert.Rule,
+		"wait":  wait.String(),
+	})
+}
+
+// flush sends the alerts held for a group
+func (r *Router) flush(key string) {
+	r.mu.Lock()
+	group := r.groups[key]
+	if group == nil {
+		r.mu.Unlock()
+		return
+	}
+	alerts := group.alerts
+	group.alerts = nil
+	group.timer = nil
+	group.lastFlush = time.Now()
+
+	// Forget the group if nothing else arrives within its interval
+	time.AfterFunc(time.Duration(*group.route.GroupInterval), func() {
+		r.expire(key)
+	})
+	r.mu.Unlock()
+
+	// Oldest alerts first, so a group reads in the order things happened
+	sort.SliceStable(alerts, func(i, j int) bool {
+		return alerts[i].StartsAt.Before(alerts[j].StartsAt)
+	})
+
+	r.logger.Debug("Sending alert group", map[string]interface{}{
+		"route":    group.route.label(),
+		"group":    key,
+		"alerts":   len(alerts),
+		"handlers": group.route.Handlers,
+	})
+
+	if err := r.dispatcher.DispatchGroup(alerts, group.route.Handlers); err != nil {
+		r.logger.Error("Failed to send routed alert group", map[string]interface{}{
+			"route": group.route.label(),
+			"group": key,
+			"error": err.Error(),
+		})
+	}
+}
+
+// expire deletes a group that holds nothing and was last sent at least a group interval ago
+// A later alert of the group starts it anew, held for the group wait
+func (r *Router) expire(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	group := r.groups[key]
+	if group == nil || group.timer != nil || len(group.alerts) > 0 {
+		return
+	}
+	if time.Since(group.lastFlush) < time.Duration(*group.route.GroupInterval) {
+		return
+	}
+	delete(r.groups, key)
+}
+
+// dispatch hands an alert to the route's handlers, logging failures
+func (r *Router) dispatch(route *Route, alert *echo.Alert) {
+	r.logger.Debug("Routing alert", map[string]interface{}{
+		"route":    route.label(),
+		"rule":     alert.Rule,
+		"status":   alert.Status,
+		"handlers": route.Handlers,
+	})
+
+	if err := r.dispatcher.Dispatch(alert, route.Handlers); err != nil {
+		r.logger.Error("Failed to send routed alert", map[string]interface{}{
+			"route": route.label(),
+			"rule":  alert.Rule,
+			"error": err.Error(),
+		})
+	}
+}
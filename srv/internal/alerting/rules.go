@@ -0,0 +1,219 @@
+package alerting
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/LissaiDev/Delphos/internal/anomaly"
+	"github.com/LissaiDev/Delphos/internal/config"
+	"github.com/LissaiDev/Delphos/internal/forecast"
+	"github.com/LissaiDev/Delphos/internal/monitor"
+)
+
+// LoadFile reads and validates an alerting configuration file
+func LoadFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &File{}
+	if err := json.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	seen := make(map[string]bool)
+	for _, rule := range file.Rules {
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+		if seen[rule.Name] {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateRule, rule.Name)
+		}
+		seen[rule.Name] = true
+	}
+
+	for _, window := range file.Maintenance {
+		if err := window.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	names := make(map[string]bool)
+	for _, policy := range file.Escalations {
+		if err := policy.Validate(); err != nil {
+			return nil, err
+		}
+		if names[policy.Name] {
+			return nil, fmt.Errorf("%w: duplicate name %s", ErrInvalidEscalation, policy.Name)
+		}
+		names[policy.Name] = true
+	}
+
+	if file.Route != nil {
+		if err := file.Route.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return file, nil
+}
+
+// DefaultRules mirrors the threshold settings from the environment
+// With a forecast horizon set, disks, inodes and swap forecast to fill up
+// within the horizon raise alerts too, as do series of the anomaly metrics
+// scoring above the anomaly threshold
+func DefaultRules() []*Rule {
+	cfg := config.Env
+	rules := []*Rule{
+		{
+			Name:      "cpu_high",
+			Metric:    "cpu.usage_avg",
+			Op:        OpGreater,
+			Threshold: cfg.CPUThreshold,
+			Severity:  SeverityWarning,
+			Processes: monitor.ProcessSortCPU,
+		},
+		{
+			Name:      "memory_high",
+			Metric:    "memory.used_percent",
+			Op:        OpGreater,
+			Threshold: cfg.MemoryThreshold,
+			Severity:  SeverityWarning,
+			Processes: monitor.ProcessSortMemory,
+		},
+		{
+			Name:      "disk_full",
+			Metric:    "disk.used_percent",
+			Op:        OpGreater,
+			Threshold: cfg.DiskThreshold,
+			Severity:  SeverityCritical,
+		},
+		{
+			Name:      "disk_io_busy",
+			Metric:    "diskio.util_percent",
+			Op:        OpGreater,
+			Threshold: cfg.IOUtilThreshold,
+			Severity:  SeverityWarning,
+		},
+	}
+
+	if cfg.ForecastHorizon > 0 {
+		horizon := float64(cfg.ForecastHorizon)
+		rules = append(rules,
+			&Rule{Name: "disk_filling", Metric: forecast.MetricDiskTimeToFull, Op: OpLess, Threshold: horizon, Severity: SeverityWarning},
+			&Rule{Name: "inodes_filling", Metric: forecast.MetricInodesTimeToFull, Op: OpLess, Threshold: horizon, Severity: SeverityWarning},
+			&Rule{Name: "swap_filling", Metric: forecast.MetricSwapTimeToFull, Op: OpLess, Threshold: horizon, Severity: SeverityWarning},
+		)
+	}
+
+	if len(cfg.AnomalyMetrics) > 0 {
+		rules = append(rules, &Rule{
+			Name:      "anomaly",
+			Metric:    anomaly.MetricScore,
+			Op:        OpGreater,
+			Threshold: cfg.AnomalyThreshold,
+			For:       Duration(time.Duration(cfg.AnomalyFor) * time.Second),
+			Severity:  SeverityWarning,
+		})
+	}
+	return rules
+}
+
+// Validate checks a rule and fills in defaults
+func (r *Rule) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidRule)
+	}
+	if r.Metric == "" {
+		return fmt.Errorf("%w: %s: metric is required", ErrInvalidRule, r.Name)
+	}
+
+	switch r.Op {
+	case OpGreater, OpGreaterEqual, OpLess, OpLessEqual, OpEqual, OpNotEqual:
+	default:
+		return fmt.Errorf("%w: %s: unknown op %q", ErrInvalidRule, r.Name, r.Op)
+	}
+
+	if r.Clear != nil {
+		switch r.Op {
+		case OpGreater, OpGreaterEqual:
+			if *r.Clear > r.Threshold {
+				return fmt.Errorf("%w: %s: clear must not be above threshold", ErrInvalidRule, r.Name)
+			}
+		case OpLess, OpLessEqual:
+			if *r.Clear < r.Threshold {
+				return fmt.Errorf("%w: %s: clear must not be below threshold", ErrInvalidRule, r.Name)
+			}
+		default:
+			return fmt.Errorf("%w: %s: clear is only supported with ordering ops", ErrInvalidRule, r.Name)
+		}
+	}
+
+	if r.For < 0 {
+		return fmt.Errorf("%w: %s: for must not be negative", ErrInvalidRule, r.Name)
+	}
+
+	switch r.Severity {
+	case "":
+		r.Severity = SeverityWarning
+	case SeverityInfo, SeverityWarning, SeverityCritical:
+	default:
+		return fmt.Errorf("%w: %s: unknown severity %q", ErrInvalidRule, r.Name, r.Severity)
+	}
+
+	switch r.Processes {
+	case "", monitor.ProcessSortCPU, monitor.ProcessSortMemory:
+	default:
+		return fmt.Errorf("%w: %s: processes must be %q or %q", ErrInvalidRule, r.Name, monitor.ProcessSortCPU, monitor.ProcessSortMemory)
+	}
+
+	return nil
+}
+
+// triggered reports whether value meets the rule's condition
+func (r *Rule) triggered(value float64) bool {
+	return compare(value, r.Op, r.Threshold)
+}
+
+// holds reports whether a firing alert should stay firing
+// With a clear threshold the alert keeps firing until the value crosses it
+func (r *Rule) holds(value float64) bool {
+	if r.Clear == nil {
+		return r.triggered(value)
+	}
+	return compare(value, r.Op, *r.Clear)
+}
+
+// worse reports whether value is further into the alert condition than current
+func (r *Rule) worse(value, current float64) bool {
+	switch r.Op {
+	case OpGreater, OpGreaterEqual:
+		return value > current
+	case OpLess, OpLessEqual:
+		return value < current
+	}
+	return math.Abs(value-r.Threshold) > math.Abs(current-r.Threshold)
+}
+
+// compare applies op to value and threshold
+func compare(value float64, op string, threshold float64) bool {
+	switch op {
+	case OpGreater:
+		return value > threshold
+	case OpGreaterEqual:
+		return value >= threshold
+	case OpLess:
+		return value < threshold
+	case OpLessEqual:
+		return value <= threshold
+	case OpEqual:
+		return value == threshold
+	case OpNotEqual:
+		return value != threshold
+	}
+	return false
+}
@@ -0,0 +1,299 @@
+package alerting
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/LissaiDev/Delphos/pkg/echo"
+	"github.com/LissaiDev/Delphos/pkg/logger"
+)
+
+// silenceRetention is how long expired silences are still listed
+const silenceRetention = 24 * time.Hour
+
+// Matchers select the alerts a silence or maintenance window applies to
+// Every set field must match; at least one has to be set
+type Matchers struct {
+	Rule     string            `json:"rule,omitempty"`     // Rule name
+	Severity string            `json:"severity,omitempty"` // Rule severity
+	Host     string            `json:"host,omitempty"`     // Host the alert was raised on
+	Labels   map[string]string `json:"labels,omitempty"`   // Label values, e.g. {"mountpoint": "/"}
+}
+
+// empty reports whether no matcher is set
+func (m Matchers) empty() bool {
+	return m.Rule == "" && m.Severity == "" && m.Host == "" && len(m.Labels) == 0
+}
+
+// matches reports whether an alert satisfies every set matcher
+func (m Matchers) matches(alert *echo.Alert) bool {
+	if m.empty() {
+		return false
+	}
+	if m.Rule != "" && m.Rule != alert.Rule {
+		return false
+	}
+	if m.Severity != "" && m.Severity != alert.Severity {
+		return false
+	}
+	if m.Host != "" && m.Host != alert.Host {
+		return false
+	}
+	return matches(alert.Labels, m.Labels)
+}
+
+// Silence mutes matching alerts between StartsAt and EndsAt
+type Silence struct {
+	ID        string    `json:"id"`                  // Random identifier
+	Matchers  Matchers  `json:"matchers"`            // Alerts the silence applies to
+	StartsAt  time.Time `json:"startsAt"`            // Start of the silence (defaults to creation time)
+	EndsAt    time.Time `json:"endsAt"`              // End of the silence
+	Comment   string    `json:"comment"`             // Why the alerts are silenced
+	CreatedBy string    `json:"createdBy,omitempty"` // Who created the silence
+	CreatedAt time.Time `json:"createdAt"`           // When the silence was created
+}
+
+// Active reports whether the silence applies at t
+func (s *Silence) Active(t time.Time) bool {
+	return !t.Before(s.StartsAt) && t.Before(s.EndsAt)
+}
+
+// Window is a recurring maintenance window defined in the alerting file
+// It opens at every time matched by Schedule and stays open for Duration
+type Window struct {
+	Name     string   `json:"name"`     // Window name, reported as the silencing reason
+	Schedule string   `json:"schedule"` // Cron expression, e.g. "0 2 * * sun"
+	Duration Duration `json:"duration"` // How long the window stays open
+	Timezone string   `json:"timezone"` // IANA zone the schedule is read in (default local time)
+	Matchers Matchers `json:"matchers"` // Alerts the window applies to
+
+	schedule *cronSchedule
+	location *time.Location
+}
+
+// Validate parses the schedule and time zone
+func (w *Window) Validate() error {
+	if w.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidWindow)
+	}
+	if w.Duration <= 0 {
+		return fmt.Errorf("%w: %s: duration must be positive", ErrInvalidWindow, w.Name)
+	}
+	if w.Matchers.empty() {
+		return fmt.Errorf("%w: %s: at least one matcher is required", ErrInvalidWindow, w.Name)
+	}
+
+	schedule, err := parseCron(w.Schedule)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidWindow, w.Name, err)
+	}
+	w.schedule = schedule
+
+	w.location = time.Local
+	if w.Timezone != "" {
+		location, err := time.LoadLocation(w.Timezone)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidWindow, w.Name, err)
+		}
+		w.location = location
+	}
+	return nil
+}
+
+// Active reports whether the window is open at t, i.e. a scheduled start
+// lies within the window's duration before t
+func (w *Window) Active(t time.Time) bool {
+	t = t.In(w.location)
+	_, ok := w.schedule.last(t, t.Add(-time.Duration(w.Duration)))
+	return ok
+}
+
+// Silences holds the silences created through the API and the configured maintenance windows
+// Silences are saved to a JSON file, when one is given, after every change
+type Silences struct {
+	logger   logger.BasicLogger
+	path     string // Empty keeps silences in memory only
+	silences []*Silence
+	windows  []*Window
+	mu       sync.Mutex
+}
+
+// NewSilences creates the silence store and loads the silences saved at path
+func NewSilences(log logger.BasicLogger, path string, windows []*Window) *Silences {
+	s := &Silences{
+		logger:  log,
+		path:    path,
+		windows: windows,
+	}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			err = json.Unmarshal(data, &s.silences)
+		}
+		if err != nil && !os.IsNotExist(err) {
+			log.Error("Failed to load silences", map[string]interface{}{
+				"path":  path,
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return s
+}
+
+// Add validates and stores a new silence, returning it with its identifier
+func (s *Silences) Add(silence Silence, now time.Time) (*Silence, error) {
+	if silence.Matchers.empty() {
+		return nil, fmt.Errorf("%w: at least one matcher is required", ErrInvalidSilence)
+	}
+	if silence.StartsAt.IsZero() {
+		silence.StartsAt = now
+	}
+	if silence.EndsAt.IsZero() {
+		return nil, fmt.Errorf("%w: endsAt is required", ErrInvalidSilence)
+	}
+	if !silence.EndsAt.After(silence.StartsAt) {
+		return nil, fmt.Errorf("%w: endsAt must be after startsAt", ErrInvalidSilence)
+	}
+	if !silence.EndsAt.After(now) {
+		return nil, fmt.Errorf("%w: endsAt is in the past", ErrInvalidSilence)
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	silence.ID = hex.EncodeToString(id)
+	silence.CreatedAt = now
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune(now)
+	s.silences = append(s.silences, &silence)
+	s.save()
+
+	s.logger.Info("Silence created", map[string]interface{}{
+		"id":         silence.ID,
+		"matchers":   silence.Matchers,
+		"starts_at":  silence.StartsAt.Format(time.RFC3339),
+		"ends_at":    silence.EndsAt.Format(time.RFC3339),
+		"created_by": silence.CreatedBy,
+	})
+
+	result := silence
+	return &result, nil
+}
+
+// Expire ends a silence at now
+func (s *Silences) Expire(id string, now time.Time) (*Silence, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, silence := range s.silences {
+		if silence.ID != id {
+			continue
+		}
+		if silence.EndsAt.After(now) {
+			silence.EndsAt = now
+			if silence.StartsAt.After(now) {
+				silence.StartsAt = now
+			}
+			s.save()
+			s.logger.Info("Silence expired", map[string]interface{}{
+				"id": id,
+			})
+		}
+		result := *silence
+		return &result, nil
+	}
+	return nil, ErrSilenceNotFound
+}
+
+// List returns the silences, including those expired within the last day, ordered by start
+func (s *Silences) List(now time.Time) []Silence {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune(now)
+	silences := make([]Silence, 0, len(s.silences))
+	for _, silence := range s.silences {
+		silences = append(silences, *silence)
+	}
+	sort.Slice(silences, func(i, j int) bool {
+		return silences[i].StartsAt.Before(silences[j].StartsAt)
+	})
+	return silences
+}
+
+// Windows returns the configured maintenance windows
+func (s *Silences) Windows() []*Window {
+	return s.windows
+}
+
+// Match returns what silences an alert at t: silence identifiers and
+// "maintenance:<name>" for open maintenance windows
+func (s *Silences) Match(alert *echo.Alert, t time.Time) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var reasons []string
+	for _, silence := range s.silences {
+		if silence.Active(t) && silence.Matchers.matches(alert) {
+			reasons = append(reasons, silence.ID)
+		}
+	}
+	for _, window := range s.windows {
+		if window.Matchers.matches(alert) && window.Active(t) {
+			reasons = append(reasons, "maintenance:"+window.Name)
+		}
+	}
+	return reasons
+}
+
+// prune drops silences that expired more than silenceRetention ago; callers hold the lock
+func (s *Silences) prune(now time.Time) {
+	kept := s.silences[:0]
+	for _, silence := range s.silences {
+		if now.Sub(silence.EndsAt) < silenceRetention {
+			kept = append(kept, silence)
+		}
+	}
+	if len(kept) != len(s.silences) {
+		s.silences = kept
+		s.save()
+	}
+}
+
+// save writes the silences to disk; callers hold the lock
+func (s *Silences) save() {
+	if s.path == "" {
+		return
+	}
+
+	err := os.MkdirAll(filepath.Dir(s.path), 0o755)
+	if err == nil {
+		var data []byte
+		data, err = json.MarshalIndent(s.silences, "", "  ")
+		if err == nil {
+			tmp := s.path + ".tmp"
+			if err = os.WriteFile(tmp, data, 0o644); err == nil {
+				err = os.Rename(tmp, s.path)
+			}
+		}
+	}
+	if err != nil {
+		s.logger.Error("Failed to save silences", map[string]interface{}{
+			"path":  s.path,
+			"error": err.Error(),
+		})
+	}
+}
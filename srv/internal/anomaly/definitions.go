@@ -0,0 +1,48 @@
+package anomaly
+
+import "time"
+
+// MetricScore carries the anomaly score (absolute z-score) of every tracked
+// series, labelled like the series plus a "metric" label naming it
+const MetricScore = "anomaly.score"
+
+// LabelMetric is the label of MetricScore naming the scored metric
+const LabelMetric = "metric"
+
+// baselinesFileName is the file in the data directory holding the baselines
+const baselinesFileName = "baselines.json"
+
+// saveInterval is the minimum time between writes of the baselines file
+const saveInterval = time.Minute
+
+// seriesExpiry is how long the baselines of a series that stopped reporting are kept
+const seriesExpiry = 7 * 24 * time.Hour
+
+// minRelativeStdDev keeps nearly constant series from scoring tiny changes as
+// anomalies: the standard deviation used for scores is at least this share of the mean
+const minRelativeStdDev = 0.01
+
+// minStdDev is the smallest standard deviation used for scores
+const minStdDev = 1e-6
+
+// hoursPerDay is the number of hour of the day baselines of a series
+const hoursPerDay = 24
+
+// Baseline is an exponentially weighted moving mean and variance
+type Baseline struct {
+	Mean     float64 `json:"mean"`
+	Variance float64 `json:"variance"`
+	Weight   float64 `json:"weight"` // Seconds of samples folded in
+	Count    int64   `json:"count"`  // Samples folded in
+}
+
+// Series holds the baselines of one metric and label set
+// Hours holds a baseline per hour of the day in local time, updated whether or
+// not seasonality is enabled so switching it on does not start from scratch
+type Series struct {
+	Metric string                `json:"metric"`
+	Labels map[string]string     `json:"labels"`
+	Last   time.Time             `json:"last"` // Time of the latest sample
+	Global Baseline              `json:"global"`
+	Hours  [hoursPerDay]Baseline `json:"hours"`
+}
@@ -0,0 +1,247 @@
+package anomaly
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/LissaiDev/Delphos/internal/config"
+	"github.com/LissaiDev/Delphos/internal/monitor"
+	"github.com/LissaiDev/Delphos/pkg/logger"
+)
+
+// Detector scores how unusual the samples of selected metrics are
+// Every series keeps an exponentially weighted baseline of its mean and
+// variance, optionally one per hour of the day, and each sample is scored by
+// how many standard deviations it lies from the baseline before it is folded in.
+// An hour of the day baseline only sees samples for an hour a day, so it
+// decays with its own half-life in wall clock time: with a week, the same hour
+// a week earlier weighs half as much as today's.
+// Baselines are saved to a JSON file, when one is given, so they survive restarts.
+type Detector struct {
+	logger       logger.BasicLogger
+	metrics      map[string]bool
+	halfLife     time.Duration
+	seasonal     bool
+	hourHalfLife time.Duration // Half-life of the hour of the day baselines
+	maxStep      time.Duration // Longest gap between samples counted as elapsed time
+	path         string        // Empty keeps baselines in memory only
+	series       map[string]*Series
+	lastSave     time.Time
+	mu           sync.Mutex
+}
+
+var (
+	detectorInstance *Detector
+	once             sync.Once
+)
+
+// New creates a detector for the given metrics and loads the baselines saved at path
+// maxStep bounds the time credited for a single sample, so a restart after
+// downtime does not wipe the baselines
+func New(log logger.BasicLogger, metrics []string, halfLife time.Duration, seasonal bool, hourHalfLife, maxStep time.Duration, path string) *Detector {
+	d := &Detector{
+		logger:       log,
+		metrics:      make(map[string]bool),
+		halfLife:     halfLife,
+		seasonal:     seasonal,
+		hourHalfLife: hourHalfLife,
+		maxStep:      maxStep,
+		path:         path,
+		series:       make(map[string]*Series),
+		lastSave:     time.Now(),
+	}
+	for _, metric := range metrics {
+		d.metrics[metric] = true
+	}
+
+	if path != "" && len(d.metrics) > 0 {
+		var saved []*Series
+		data, err := os.ReadFile(path)
+		if err == nil {
+			err = json.Unmarshal(data, &saved)
+		}
+		if err != nil && !os.IsNotExist(err) {
+			log.Error("Failed to load anomaly baselines", map[string]interface{}{
+				"path":  path,
+				"error": err.Error(),
+			})
+		}
+		for _, s := range saved {
+			if d.metrics[s.Metric] {
+				d.series[monitor.SeriesKey(s.Metric, s.Labels)] = s
+			}
+		}
+	}
+
+	return d
+}
+
+// GetInstance returns the shared detector configured from config.Env
+func GetInstance() *Detector {
+	once.Do(func() {
+		path := ""
+		if config.Env.DataDir != "" {
+			path = filepath.Join(config.Env.DataDir, baselinesFileName)
+		}
+		detectorInstance = New(
+			logger.GetInstance(),
+			config.Env.AnomalyMetrics,
+			time.Duration(config.Env.AnomalyHalfLife)*time.Second,
+			config.Env.AnomalySeasonal,
+			time.Duration(config.Env.AnomalyHourHalfLife)*time.Second,
+			2*time.Duration(config.Env.Interval)*time.Second,
+			path,
+		)
+	})
+	return detectorInstance
+}
+
+// Enabled reports whether any metric is tracked
+func (d *Detector) Enabled() bool {
+	return len(d.metrics) > 0
+}
+
+// Observe scores a batch of samples taken at the given time and folds them into the baselines
+// It returns an anomaly score sample for every tracked series whose baseline is
+// warmed up, i.e. has seen at least a half-life of samples
+func (d *Detector) Observe(at time.Time, samples []monitor.Sample) []monitor.Sample {
+	if !d.Enabled() {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var scores []monitor.Sample
+	for _, sample := range samples {
+		if !d.metrics[sample.Name] || sample.Type == monitor.MetricCounter {
+			continue
+		}
+
+		key := sample.Key()
+		s := d.series[key]
+		if s == nil {
+			s = &Series{Metric: sample.Name, Labels: sample.Labels}
+			d.series[key] = s
+		}
+
+		var step time.Duration
+		if !s.Last.IsZero() {
+			step = min(max(at.Sub(s.Last), 0), d.maxStep)
+		}
+		// An hour of the day baseline sees a twenty-fourth of the wall clock time
+		alpha := smoothing(step, d.halfLife)
+		hourAlpha := smoothing(step*hoursPerDay, d.hourHalfLife)
+
+		hour := &s.Hours[at.Local().Hour()]
+		baseline := &s.Global
+		if d.seasonal {
+			baseline = hour
+		}
+		if score, ok := baseline.score(sample.Value, d.halfLife); ok {
+			labels := make(map[string]string, len(sample.Labels)+1)
+			for k, v := range sample.Labels {
+				labels[k] = v
+			}
+			labels[LabelMetric] = sample.Name
+			scores = append(scores, monitor.Sample{
+				Name:   MetricScore,
+				Type:   monitor.MetricGauge,
+				Labels: labels,
+				Value:  score,
+			})
+		}
+
+		s.Global.update(sample.Value, alpha, step)
+		hour.update(sample.Value, hourAlpha, step)
+		s.Last = at
+	}
+
+	for key, s := range d.series {
+		if at.Sub(s.Last) > seriesExpiry {
+			delete(d.series, key)
+		}
+	}
+
+	if at.Sub(d.lastSave) >= saveInterval {
+		d.save()
+		d.lastSave = at
+	}
+
+	return scores
+}
+
+// Close saves the baselines
+func (d *Detector) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.Enabled() {
+		d.save()
+	}
+	return nil
+}
+
+// smoothing returns the weight of a sample taken step after the previous one
+// in a baseline with the given half-life
+func smoothing(step, halfLife time.Duration) float64 {
+	return 1 - math.Exp(-math.Ln2*step.Seconds()/halfLife.Seconds())
+}
+
+// score returns the absolute z-score of a value, ok is false while the baseline warms up
+func (b *Baseline) score(value float64, warmup time.Duration) (float64, bool) {
+	if b.Count == 0 || b.Weight < warmup.Seconds() {
+		return 0, false
+	}
+	stdDev := max(math.Sqrt(b.Variance), minRelativeStdDev*math.Abs(b.Mean), minStdDev)
+	return math.Abs(value-b.Mean) / stdDev, true
+}
+
+// update folds a value into the baseline with the given smoothing factor
+func (b *Baseline) update(value, alpha float64, step time.Duration) {
+	if b.Count == 0 {
+		b.Mean = value
+		b.Variance = 0
+	} else {
+		diff := value - b.Mean
+		increment := alpha * diff
+		b.Mean += increment
+		b.Variance = (1 - alpha) * (b.Variance + diff*increment)
+	}
+	b.Weight += step.Seconds()
+	b.Count++
+}
+
+// save writes the baselines to disk; callers hold the lock
+func (d *Detector) save() {
+	if d.path == "" {
+		return
+	}
+
+	series := make([]*Series, 0, len(d.series))
+	for _, s := range d.series {
+		series = append(series, s)
+	}
+
+	err := os.MkdirAll(filepath.Dir(d.path), 0o755)
+	if err == nil {
+		var data []byte
+		data, err = json.Marshal(series)
+		if err == nil {
+			tmp := d.path + ".tmp"
+			if err = os.WriteFile(tmp, data, 0o644); err == nil {
+				err = os.Rename(tmp, d.path)
+			}
+		}
+	}
+	if err != nil {
+		d.logger.Error("Failed to save anomaly baselines", map[string]interface{}{
+			"path":  d.path,
+			"error": err.Error(),
+		})
+	}
+}
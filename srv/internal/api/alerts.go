@@ -0,0 +1,221 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/LissaiDev/Delphos/internal/alerting"
+	"github.com/LissaiDev/Delphos/pkg/logger"
+)
+
+// Paging of alert history queries
+const (
+	defaultAlertHistoryLimit = 100
+	maxAlertHistoryLimit     = 1000
+)
+
+// maxAckBody bounds the size of an acknowledgement request
+const maxAckBody = 16 * 1024
+
+// AlertsResponse is the body served by AlertsHandler
+type AlertsResponse struct {
+	Alerts []alerting.State `json:"alerts"` // Pending and firing alerts, ordered by rule
+}
+
+// AlertHistoryResponse is the body served by AlertHistoryHandler
+type AlertHistoryResponse struct {
+	Events []alerting.Event `json:"events"` // Matching state changes, newest first
+	Total  int              `json:"total"`  // Number of matching state changes before paging
+	Limit  int              `json:"limit"`
+	Offset int              `json:"offset"`
+}
+
+// ackRequest is the body of an acknowledgement request
+type ackRequest struct {
+	Fingerprint string `json:"fingerprint"` // Alert acknowledged
+	By          string `json:"by"`          // Who is handling the alert
+	Note        string `json:"note"`        // Optional note, e.g. what is being done
+}
+
+// AlertsHandler reports the alerts currently pending or firing
+// Each alert carries when its condition was first met, when it fired and its latest value
+func AlertsHandler(w http.ResponseWriter, r *http.Request) {
+	log := logger.GetInstance()
+
+	response := AlertsResponse{Alerts: []alerting.State{}}
+	for _, st := range alerting.GetInstance().States() {
+		if st.Status == alerting.StatePending || st.Status == alerting.StateFiring {
+			response.Alerts = append(response.Alerts, st)
+		}
+	}
+
+	log.Debug("Active alerts reported", map[string]interface{}{
+		"endpoint": "/api/alerts",
+		"alerts":   len(response.Alerts),
+	})
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// AlertHistoryHandler pages through the alert log
+// Accepts optional "rule", "severity", "status" and "fingerprint" filters, "from"/"to"
+// (Unix seconds or RFC3339), "limit" (default 100, at most 1000) and "offset"
+func AlertHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	log := logger.GetInstance()
+
+	alertLog := alerting.GetInstance().AlertLog()
+	if alertLog == nil {
+		http.Error(w, "alert log is disabled", http.StatusNotFound)
+		return
+	}
+
+	query, err := parseAlertHistoryQuery(r)
+	if err != nil {
+		log.Warn("Invalid alert history query", map[string]interface{}{
+			"query": r.URL.RawQuery,
+			"error": err.Error(),
+		})
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	events, total := alertLog.Query(query)
+	if events == nil {
+		events = []alerting.Event{}
+	}
+
+	log.Debug("Alert history queried", map[string]interface{}{
+		"endpoint": "/api/alerts/history",
+		"rule":     query.Rule,
+		"severity": query.Severity,
+		"events":   len(events),
+		"total":    total,
+	})
+
+	writeJSON(w, http.StatusOK, AlertHistoryResponse{
+		Events: events,
+		Total:  total,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	})
+}
+
+// AckHandler manages alert acknowledgements
+// POST acknowledges the firing alert named in a JSON body (fingerprint, by, note),
+// stopping its repeat notifications and escalations; DELETE withdraws the
+// acknowledgement of the alert named by the "fingerprint" parameter
+func AckHandler(w http.ResponseWriter, r *http.Request) {
+	log := logger.GetInstance()
+	engine := alerting.GetInstance()
+	now := time.Now()
+
+	var (
+		st  *alerting.State
+		err error
+	)
+	switch r.Method {
+	case http.MethodPost:
+		var request ackRequest
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAckBody))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&request); err != nil {
+			log.Warn("Invalid acknowledgement request", map[string]interface{}{
+				"error": err.Error(),
+			})
+			http.Error(w, "invalid acknowledgement: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		st, err = engine.Acknowledge(request.Fingerprint, request.By, request.Note, now)
+
+	case http.MethodDelete:
+		fingerprint := r.URL.Query().Get("fingerprint")
+		if fingerprint == "" {
+			http.Error(w, "fingerprint is required", http.StatusBadRequest)
+			return
+		}
+		st, err = engine.Unacknowledge(fingerprint, now)
+
+	default:
+		w.Header().Set("Allow", "POST, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, alerting.ErrInvalidAck):
+			status = http.StatusBadRequest
+		case errors.Is(err, alerting.ErrAlertNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, alerting.ErrAlertNotFiring):
+			status = http.StatusConflict
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	writeJSON(w, http.StatusOK, st)
+}
+
+// parseAlertHistoryQuery builds an alert log query from the request parameters
+func parseAlertHistoryQuery(r *http.Request) (alerting.LogQuery, error) {
+	values := r.URL.Query()
+
+	query := alerting.LogQuery{
+		Rule:        values.Get("rule"),
+		Severity:    values.Get("severity"),
+		Status:      values.Get("status"),
+		Fingerprint: values.Get("fingerprint"),
+		Limit:       defaultAlertHistoryLimit,
+	}
+
+	switch query.Severity {
+	case "", alerting.SeverityInfo, alerting.SeverityWarning, alerting.SeverityCritical:
+	default:
+		return query, errors.New("severity must be info, warning or critical")
+	}
+	switch query.Status {
+	case "", alerting.StatePending, alerting.StateFiring, alerting.StateResolved, alerting.EventInactive,
+		alerting.EventAcknowledged, alerting.EventUnacknowledged, alerting.EventEscalated:
+	default:
+		return query, errors.New("status must be pending, firing, resolved, inactive, acknowledged, unacknowledged or escalated")
+	}
+
+	if v := values.Get("from"); v != "" {
+		t, err := parseHistoryTime(v)
+		if err != nil {
+			return query, errors.New("from must be Unix seconds or RFC3339")
+		}
+		query.From = t
+	}
+	if v := values.Get("to"); v != "" {
+		t, err := parseHistoryTime(v)
+		if err != nil {
+			return query, errors.New("to must be Unix seconds or RFC3339")
+		}
+		query.To = t
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && query.To.Before(query.From) {
+		return query, errors.New("to must not be before from")
+	}
+
+	if v := values.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			return query, errors.New("limit must be a positive integer")
+		}
+		query.Limit = min(limit, maxAlertHistoryLimit)
+	}
+	if v := values.Get("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			return query, errors.New("offset must be a non-negative integer")
+		}
+		query.Offset = offset
+	}
+
+	return query, nil
+}
@@ -57,7 +57,13 @@ func (b *Broker) Stop() {
 }
 
 func (b *Broker) Broadcast(msg string) {
-	b.message <- msg
+	b.message <- "data: " + msg + "\n\n"
+}
+
+// BroadcastEvent sends a named event, received by clients listening for that event
+// name instead of through onmessage
+func (b *Broker) BroadcastEvent(event, msg string) {
+	b.message <- "event: " + event + "\ndata: " + msg + "\n\n"
 }
 
 func (b *Broker) AddClient(client chan string) {
@@ -107,7 +113,7 @@ func (b *Broker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}()
 
 	for msg := range clientChan {
-		w.Write([]byte("data: " + msg + "\n\n"))
+		w.Write([]byte(msg))
 		flusher.Flush()
 	}
 }
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/LissaiDev/Delphos/internal/forecast"
+	"github.com/LissaiDev/Delphos/pkg/logger"
+)
+
+// ForecastResponse is the body served by ForecastHandler
+type ForecastResponse struct {
+	Window    int64               `json:"window"`    // Seconds of history fitted
+	Forecasts []forecast.Forecast `json:"forecasts"` // One entry per disk, inode table and swap with enough history
+}
+
+// ForecastHandler reports when disks, inode tables and swap are expected to fill up
+func ForecastHandler(w http.ResponseWriter, r *http.Request) {
+	log := logger.GetInstance()
+	forecaster := forecast.GetInstance()
+
+	response := ForecastResponse{
+		Window:    int64(forecaster.Window().Seconds()),
+		Forecasts: forecaster.Forecasts(),
+	}
+
+	log.Debug("Forecasts reported", map[string]interface{}{
+		"endpoint":  "/api/forecast",
+		"forecasts": len(response.Forecasts),
+	})
+
+	writeJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,250 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LissaiDev/Delphos/internal/anomaly"
+	"github.com/LissaiDev/Delphos/internal/config"
+	"github.com/LissaiDev/Delphos/internal/history"
+	"github.com/LissaiDev/Delphos/internal/monitor"
+	"github.com/LissaiDev/Delphos/pkg/logger"
+)
+
+// Defaults for range queries that omit parameters
+const (
+	defaultHistoryRange  = time.Hour
+	defaultHistoryPoints = 500 // Target number of steps when no step is given
+)
+
+// historyParams are the query parameters consumed by HistoryHandler
+// Other parameters are rejected unless they carry historyLabelPrefix
+var historyParams = map[string]bool{"metric": true, "from": true, "to": true, "step": true, "agg": true, "anomaly": true}
+
+// historyLabelPrefix marks a query parameter as a label matcher (e.g. label.cpu=cpu0)
+const historyLabelPrefix = "label."
+
+// HistoryHandler handles range queries against the metric history
+// Accepts "metric", optional "from"/"to" (Unix seconds or RFC3339, default the last hour),
+// optional "step" (seconds or a duration such as "1m"), optional "agg" (avg, min, max or last)
+// and label matchers written as label.<name>=<value>. Unknown parameters are
+// rejected, so a typo does not silently match nothing. The stored resolution is picked from the range and step.
+// With "anomaly" set to true, each series carries its anomaly scores as well.
+func HistoryHandler(w http.ResponseWriter, r *http.Request) {
+	log := logger.GetInstance()
+	store := history.GetInstance()
+
+	if !store.Enabled() {
+		log.Warn("History requested while disabled", map[string]interface{}{})
+		http.Error(w, "history is disabled", http.StatusNotFound)
+		return
+	}
+
+	query, err := parseHistoryQuery(r)
+	if err != nil {
+		log.Warn("Invalid history query", map[string]interface{}{
+			"query": r.URL.RawQuery,
+			"error": err.Error(),
+		})
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	log.Info("Querying metric history", map[string]interface{}{
+		"endpoint": "/api/history",
+		"metric":   query.Metric,
+		"from":     query.From.Format(time.RFC3339),
+		"to":       query.To.Format(time.RFC3339),
+		"step":     query.Step.String(),
+		"matchers": query.Matchers,
+	})
+
+	startTime := time.Now()
+	result, err := store.Query(query)
+	queryTime := time.Since(startTime)
+
+	if err != nil {
+		log.Warn("Failed to query metric history", map[string]interface{}{
+			"error":      err.Error(),
+			"query_time": queryTime.String(),
+		})
+		status := http.StatusInternalServerError
+		if isHistoryQueryError(err) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	if withAnomaly, _ := strconv.ParseBool(r.URL.Query().Get("anomaly")); withAnomaly {
+		if err := attachAnomalyScores(store, query, result); err != nil {
+			log.Warn("Failed to query anomaly scores", map[string]interface{}{
+				"metric": query.Metric,
+				"error":  err.Error(),
+			})
+		}
+	}
+
+	log.Info("Metric history queried successfully", map[string]interface{}{
+		"query_time": queryTime.String(),
+		"resolution": result.Resolution,
+		"series":     len(result.Series),
+		"points":     len(result.Timestamps),
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		log.Error("Failed to encode JSON response", map[string]interface{}{
+			"error": err.Error(),
+		})
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// attachAnomalyScores adds to each series of a result the highest anomaly score
+// recorded for it in every step
+func attachAnomalyScores(store *history.Store, query history.Query, result *history.QueryResult) error {
+	matchers := maps.Clone(query.Matchers)
+	matchers[anomaly.LabelMetric] = query.Metric
+
+	scores, err := store.Query(history.Query{
+		Metric:    anomaly.MetricScore,
+		Matchers:  matchers,
+		From:      query.From,
+		To:        query.To,
+		Step:      time.Duration(result.Step) * time.Second,
+		Aggregate: history.AggregateMax,
+	})
+	if err != nil {
+		return err
+	}
+	if scores.From != result.From || len(scores.Timestamps) != len(result.Timestamps) {
+		return nil
+	}
+
+	byLabels := make(map[string][]*float64, len(scores.Series))
+	for _, series := range scores.Series {
+		labels := maps.Clone(series.Labels)
+		delete(labels, anomaly.LabelMetric)
+		byLabels[monitor.SeriesKey("", labels)] = series.Values
+	}
+	for _, series := range result.Series {
+		series.Anomaly = byLabels[monitor.SeriesKey("", series.Labels)]
+	}
+	return nil
+}
+
+// parseHistoryQuery builds a history query from the request parameters
+func parseHistoryQuery(r *http.Request) (history.Query, error) {
+	values := r.URL.Query()
+
+	query := history.Query{
+		Metric:    values.Get("metric"),
+		Matchers:  make(map[string]string),
+		To:        time.Now(),
+		Aggregate: values.Get("agg"),
+	}
+	if query.Metric == "" {
+		return query, history.ErrMissingMetric
+	}
+
+	if v := values.Get("to"); v != "" {
+		t, err := parseHistoryTime(v)
+		if err != nil {
+			return query, errors.New("to must be Unix seconds or RFC3339")
+		}
+		query.To = t
+	}
+
+	query.From = query.To.Add(-defaultHistoryRange)
+	if v := values.Get("from"); v != "" {
+		t, err := parseHistoryTime(v)
+		if err != nil {
+			return query, errors.New("from must be Unix seconds or RFC3339")
+		}
+		query.From = t
+	}
+	if query.To.Before(query.From) {
+		return query, history.ErrInvalidRange
+	}
+
+	if v := values.Get("step"); v != "" {
+		step, err := parseHistoryStep(v)
+		if err != nil {
+			return query, err
+		}
+		query.Step = step
+	} else {
+		query.Step = defaultHistoryStep(query.To.Sub(query.From))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		if label, ok := strings.CutPrefix(name, historyLabelPrefix); ok {
+			if label == "" {
+				return query, errors.New("label matchers need a label name, e.g. label.cpu=cpu0")
+			}
+			query.Matchers[label] = values.Get(name)
+			continue
+		}
+		if !historyParams[name] {
+			return query, fmt.Errorf("unknown parameter %q, label matchers are written as label.<name>=<value>", name)
+		}
+	}
+
+	return query, nil
+}
+
+// parseHistoryTime accepts Unix seconds (optionally fractional) or RFC3339
+func parseHistoryTime(value string) (time.Time, error) {
+	if secs, err := strconv.ParseFloat(value, 64); err == nil {
+		whole, frac := math.Modf(secs)
+		return time.Unix(int64(whole), int64(frac*1e9)), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// parseHistoryStep accepts a number of seconds or a Go duration
+func parseHistoryStep(value string) (time.Duration, error) {
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs <= 0 {
+			return 0, history.ErrInvalidStep
+		}
+		return time.Duration(secs) * time.Second, nil
+	}
+	step, err := time.ParseDuration(value)
+	if err != nil || step < time.Second || step%time.Second != 0 {
+		return 0, history.ErrInvalidStep
+	}
+	return step, nil
+}
+
+// defaultHistoryStep picks the collection interval, widened so the range
+// spans about defaultHistoryPoints steps
+func defaultHistoryStep(span time.Duration) time.Duration {
+	step := time.Duration(config.Env.Interval) * time.Second
+	if step < time.Second {
+		step = time.Second
+	}
+	if wide := span / defaultHistoryPoints; wide > step {
+		step = wide.Truncate(time.Second) + time.Second
+	}
+	return step
+}
+
+// isHistoryQueryError reports whether err was caused by the query rather than the storage
+func isHistoryQueryError(err error) bool {
+	return errors.Is(err, history.ErrMissingMetric) ||
+		errors.Is(err, history.ErrInvalidRange) ||
+		errors.Is(err, history.ErrInvalidStep) ||
+		errors.Is(err, history.ErrInvalidAggregate) ||
+		errors.Is(err, history.ErrTooManyPoints)
+}
@@ -1,9 +1,12 @@
 package api
 
 import (
+	"crypto/subtle"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/LissaiDev/Delphos/internal/config"
 	"github.com/LissaiDev/Delphos/pkg/logger"
 )
 
@@ -17,9 +20,15 @@ func SecurityMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-// CORSMiddleware adds CORS headers to all responses
+// CORSMiddleware adds CORS headers to the responses of read-only requests
+// Requests that change state get none, so pages on other origins cannot make
+// them through a browser
 func CORSMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !safeMethod(r.Method) {
+			next.ServeHTTP(w, r)
+			return
+		}
 		setCORSHeaders(w)
 		if r.Method == http.MethodOptions {
 			logCORSPreflight(r)
@@ -30,6 +39,35 @@ func CORSMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// TokenAuthMiddleware requires the configured API token on requests that change state
+// Methods other than GET, HEAD and OPTIONS must send "Authorization: Bearer <token>",
+// and are refused altogether while no token is configured
+func TokenAuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if safeMethod(r.Method) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token := config.Env.APIToken
+		if token == "" {
+			logUnauthorized(r, "no API token configured")
+			http.Error(w, "requests that change state are disabled, set API_TOKEN to enable them", http.StatusForbidden)
+			return
+		}
+
+		provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			logUnauthorized(r, "missing or invalid API token")
+			w.Header().Set("WWW-Authenticate", `Bearer realm="delphos"`)
+			http.Error(w, "missing or invalid API token", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 // StreamingSecurityMiddleware adds basic security headers for streaming
 func StreamingSecurityMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -61,7 +99,7 @@ func logRequestStart(r *http.Request) {
 		"url":         r.URL.String(),
 		"remote_addr": r.RemoteAddr,
 		"user_agent":  r.UserAgent(),
-		"headers":     r.Header,
+		"headers":     redactHeaders(r.Header),
 		"host":        r.Host,
 		"proto":       r.Proto,
 	})
@@ -186,11 +224,40 @@ func logStreamingCORSPreflight(r *http.Request) {
 	})
 }
 
+// safeMethod reports whether a request method only reads state
+func safeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// redactHeaders returns a copy of request headers without credential values, for logging
+func redactHeaders(headers http.Header) http.Header {
+	redacted := headers.Clone()
+	for _, name := range []string{"Authorization", "Cookie"} {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, "[REDACTED]")
+		}
+	}
+	return redacted
+}
+
+// logUnauthorized logs a refused request that changes state
+func logUnauthorized(r *http.Request, reason string) {
+	log := logger.GetInstance()
+
+	log.Warn("Unauthorized request refused", map[string]interface{}{
+		"method":      r.Method,
+		"url":         r.URL.String(),
+		"remote_addr": r.RemoteAddr,
+		"origin":      r.Header.Get("Origin"),
+		"reason":      reason,
+	})
+}
+
 // setCORSHeaders sets CORS headers
 func setCORSHeaders(w http.ResponseWriter) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-Requested-With")
 	w.Header().Set("Access-Control-Max-Age", "86400") // 24 hours
 }
 
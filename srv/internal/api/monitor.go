@@ -3,14 +3,16 @@ package api
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"time"
 
+	"github.com/LissaiDev/Delphos/internal/config"
 	"github.com/LissaiDev/Delphos/internal/monitor"
 	"github.com/LissaiDev/Delphos/pkg/logger"
 )
 
 // SystemStatsHandler handles HTTP requests for system monitoring statistics
-// Returns JSON response with current system metrics
+// Returns JSON response with the latest collected system metrics
 func SystemStatsHandler(w http.ResponseWriter, r *http.Request) {
 	log := logger.GetInstance()
 	log.Info("Generating system statistics", map[string]interface{}{
@@ -20,7 +22,7 @@ func SystemStatsHandler(w http.ResponseWriter, r *http.Request) {
 
 	// Generate system statistics
 	startTime := time.Now()
-	stats, err := monitor.GetSystemStats()
+	stats, err := monitor.GetLatestSystemStats()
 	generationTime := time.Since(startTime)
 
 	if err != nil {
@@ -32,13 +34,27 @@ func SystemStatsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Partial results are still served; the payload's errors section explains what is missing
+	if len(stats.Errors) > 0 {
+		log.Warn("System statistics generated with missing collectors", map[string]interface{}{
+			"collector_errors": len(stats.Errors),
+		})
+	}
+
 	// Log successful statistics generation
 	log.Info("System statistics generated successfully", map[string]interface{}{
-		"generation_time":    generationTime.String(),
-		"hostname":           stats.Host.Hostname,
+		"generation_time": generationTime.String(),
+		"hostname": func() string {
+			if stats.Host != nil {
+				return stats.Host.Hostname
+			}
+			return ""
+		}(),
 		"cpu_cores":          len(stats.CPU),
 		"disk_partitions":    len(stats.Disk),
+		"disk_devices":       len(stats.DiskIO),
 		"network_interfaces": len(stats.Network),
+		"processes":          len(stats.Processes),
 	})
 
 	// Set response headers and encode JSON
@@ -56,3 +72,67 @@ func SystemStatsHandler(w http.ResponseWriter, r *http.Request) {
 		"method":   r.Method,
 	})
 }
+
+// ProcessStatsHandler handles HTTP requests for per-process statistics
+// Accepts optional "limit" (top-N, 0 for all) and "sort" ("cpu" or "memory") query parameters
+func ProcessStatsHandler(w http.ResponseWriter, r *http.Request) {
+	log := logger.GetInstance()
+
+	limit := config.Env.ProcessLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		v, err := strconv.Atoi(limitStr)
+		if err != nil || v < 0 {
+			log.Warn("Invalid process limit requested", map[string]interface{}{
+				"limit": limitStr,
+			})
+			http.Error(w, "limit must be a non-negative integer", http.StatusBadRequest)
+			return
+		}
+		limit = v
+	}
+
+	sortBy := r.URL.Query().Get("sort")
+	if sortBy == "" {
+		sortBy = monitor.ProcessSortCPU
+	}
+	if sortBy != monitor.ProcessSortCPU && sortBy != monitor.ProcessSortMemory {
+		log.Warn("Invalid process sort requested", map[string]interface{}{
+			"sort": sortBy,
+		})
+		http.Error(w, "sort must be one of: cpu, memory", http.StatusBadRequest)
+		return
+	}
+
+	log.Info("Generating process statistics", map[string]interface{}{
+		"endpoint": "/api/stats/processes",
+		"limit":    limit,
+		"sort":     sortBy,
+	})
+
+	startTime := time.Now()
+	processes, err := monitor.GetProcessInfo(limit, sortBy)
+	generationTime := time.Since(startTime)
+
+	if err != nil {
+		log.Error("Failed to generate process statistics", map[string]interface{}{
+			"error":           err.Error(),
+			"generation_time": generationTime.String(),
+		})
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	log.Info("Process statistics generated successfully", map[string]interface{}{
+		"generation_time": generationTime.String(),
+		"processes":       len(processes),
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(processes); err != nil {
+		log.Error("Failed to encode JSON response", map[string]interface{}{
+			"error": err.Error(),
+		})
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
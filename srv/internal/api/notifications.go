@@ -0,0 +1,41 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/LissaiDev/Delphos/pkg/echo"
+	"github.com/LissaiDev/Delphos/pkg/logger"
+)
+
+// queueReporter is implemented by notifiers that deliver through queues
+type queueReporter interface {
+	Queues() []echo.QueueStats
+}
+
+// NotificationsResponse is the body served by NotificationsHandler
+type NotificationsResponse struct {
+	Queues []echo.QueueStats `json:"queues"` // One entry per notification handler
+}
+
+// NotificationsHandler reports the notification queues
+// Each handler has a queue with its depth, delivery counters and latest error
+func NotificationsHandler(w http.ResponseWriter, r *http.Request) {
+	log := logger.GetInstance()
+
+	response := NotificationsResponse{Queues: []echo.QueueStats{}}
+	if reporter, ok := echo.GetInstance().(queueReporter); ok {
+		response.Queues = reporter.Queues()
+	}
+
+	depth := 0
+	for _, queue := range response.Queues {
+		depth += queue.Depth
+	}
+	log.Debug("Notification queues reported", map[string]interface{}{
+		"endpoint": "/api/notifications",
+		"queues":   len(response.Queues),
+		"depth":    depth,
+	})
+
+	writeJSON(w, http.StatusOK, response)
+}
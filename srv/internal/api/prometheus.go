@@ -0,0 +1,185 @@
+package api
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LissaiDev/Delphos/internal/monitor"
+	"github.com/LissaiDev/Delphos/pkg/logger"
+)
+
+// Exposition content types
+const (
+	prometheusContentType  = "text/plain; version=0.0.4; charset=utf-8"
+	openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+)
+
+// metricPrefix namespaces every exported metric
+const metricPrefix = "delphos_"
+
+// metricFamily groups the samples sharing a metric name
+type metricFamily struct {
+	name    string
+	samples []monitor.Sample
+}
+
+// PrometheusHandler exposes the latest collected system statistics for Prometheus scraping
+// Scrapes read the result of the periodic collection rather than collecting
+// themselves, so they neither disturb rates nor wait on slow collectors.
+// Serves the OpenMetrics format when the scraper asks for it and the
+// Prometheus text format otherwise
+func PrometheusHandler(w http.ResponseWriter, r *http.Request) {
+	log := logger.GetInstance()
+	openMetrics := acceptsOpenMetrics(r.Header.Get("Accept"))
+
+	startTime := time.Now()
+	stats, err := monitor.GetLatestSystemStats()
+	generationTime := time.Since(startTime)
+
+	if err != nil {
+		log.Error("Failed to generate system statistics for scrape", map[string]interface{}{
+			"error":           err.Error(),
+			"generation_time": generationTime.String(),
+		})
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	samples := stats.Samples()
+
+	log.Debug("Metrics scrape generated", map[string]interface{}{
+		"generation_time":  generationTime.String(),
+		"samples":          len(samples),
+		"open_metrics":     openMetrics,
+		"collector_errors": len(stats.Errors),
+	})
+
+	if openMetrics {
+		w.Header().Set("Content-Type", openMetricsContentType)
+	} else {
+		w.Header().Set("Content-Type", prometheusContentType)
+	}
+	if err := writeExposition(w, samples, openMetrics); err != nil {
+		log.Error("Failed to write metrics response", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+}
+
+// acceptsOpenMetrics reports whether the Accept header asks for OpenMetrics
+func acceptsOpenMetrics(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		if mediaType == "application/openmetrics-text" {
+			return true
+		}
+	}
+	return false
+}
+
+// writeExposition renders samples in the Prometheus text or OpenMetrics format
+func writeExposition(out io.Writer, samples []monitor.Sample, openMetrics bool) error {
+	w := bufio.NewWriter(out)
+
+	for _, family := range groupFamilies(samples) {
+		desc, _ := monitor.DescribeMetric(family.name)
+		metricType := desc.Type
+		if metricType == "" {
+			metricType = monitor.MetricGauge
+		}
+
+		name := metricPrefix + strings.ReplaceAll(family.name, ".", "_")
+		sampleName := name
+		if metricType == monitor.MetricCounter {
+			sampleName = name + "_total"
+			if !openMetrics {
+				// The text format names counter families after their samples
+				name = sampleName
+			}
+		}
+
+		if desc.Help != "" {
+			w.WriteString("# HELP " + name + " " + escapeHelp(desc.Help) + "\n")
+		}
+		w.WriteString("# TYPE " + name + " " + metricType + "\n")
+
+		for _, sample := range family.samples {
+			w.WriteString(sampleName)
+			writeLabels(w, sample.Labels)
+			w.WriteString(" " + formatSampleValue(sample.Value) + "\n")
+		}
+	}
+
+	if openMetrics {
+		w.WriteString("# EOF\n")
+	}
+	return w.Flush()
+}
+
+// groupFamilies collects samples by metric name in order of first appearance
+func groupFamilies(samples []monitor.Sample) []*metricFamily {
+	var families []*metricFamily
+	index := make(map[string]*metricFamily)
+
+	for _, sample := range samples {
+		family, exists := index[sample.Name]
+		if !exists {
+			family = &metricFamily{name: sample.Name}
+			index[sample.Name] = family
+			families = append(families, family)
+		}
+		family.samples = append(family.samples, sample)
+	}
+	return families
+}
+
+// writeLabels renders a label set as {k="v",...} with sorted keys
+func writeLabels(w *bufio.Writer, labels map[string]string) {
+	if len(labels) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	w.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			w.WriteByte(',')
+		}
+		w.WriteString(k + `="` + escapeLabelValue(labels[k]) + `"`)
+	}
+	w.WriteByte('}')
+}
+
+// escapeHelp escapes backslashes and newlines in HELP text
+func escapeHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+// escapeLabelValue escapes backslashes, quotes and newlines in label values
+func escapeLabelValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// formatSampleValue renders a sample value, spelling out special values
+func formatSampleValue(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
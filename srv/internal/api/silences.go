@@ -0,0 +1,127 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/LissaiDev/Delphos/internal/alerting"
+	"github.com/LissaiDev/Delphos/pkg/logger"
+)
+
+// maxSilenceBody bounds the size of a silence creation request
+const maxSilenceBody = 64 * 1024
+
+// SilenceStatus is a silence with whether it currently applies
+type SilenceStatus struct {
+	alerting.Silence
+	Active bool `json:"active"`
+}
+
+// WindowStatus is a maintenance window with whether it is currently open
+type WindowStatus struct {
+	*alerting.Window
+	Active bool `json:"active"`
+}
+
+// SilencesResponse is the body served for GET requests by SilencesHandler
+type SilencesResponse struct {
+	Silences    []SilenceStatus `json:"silences"`    // Silences, including those expired within the last day
+	Maintenance []WindowStatus  `json:"maintenance"` // Maintenance windows from the alerting file
+}
+
+// silenceRequest is the body of a silence creation request
+// Duration may be given instead of endsAt
+type silenceRequest struct {
+	alerting.Silence
+	Duration alerting.Duration `json:"duration"`
+}
+
+// SilencesHandler manages alert silences
+// GET lists silences and maintenance windows, POST creates a silence from a JSON body
+// (matchers, startsAt, endsAt or duration, comment, createdBy) and DELETE expires
+// the silence named by the "id" parameter
+func SilencesHandler(w http.ResponseWriter, r *http.Request) {
+	log := logger.GetInstance()
+	silences := alerting.GetInstance().Silences()
+	now := time.Now()
+
+	switch r.Method {
+	case http.MethodGet:
+		response := SilencesResponse{
+			Silences:    []SilenceStatus{},
+			Maintenance: []WindowStatus{},
+		}
+		for _, silence := range silences.List(now) {
+			response.Silences = append(response.Silences, SilenceStatus{Silence: silence, Active: silence.Active(now)})
+		}
+		for _, window := range silences.Windows() {
+			response.Maintenance = append(response.Maintenance, WindowStatus{Window: window, Active: window.Active(now)})
+		}
+		writeJSON(w, http.StatusOK, response)
+
+	case http.MethodPost:
+		var request silenceRequest
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSilenceBody))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&request); err != nil {
+			log.Warn("Invalid silence request", map[string]interface{}{
+				"error": err.Error(),
+			})
+			http.Error(w, "invalid silence: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		silence := request.Silence
+		if silence.EndsAt.IsZero() && request.Duration > 0 {
+			start := silence.StartsAt
+			if start.IsZero() {
+				start = now
+			}
+			silence.EndsAt = start.Add(time.Duration(request.Duration))
+		}
+
+		created, err := silences.Add(silence, now)
+		if err != nil {
+			log.Warn("Failed to create silence", map[string]interface{}{
+				"error": err.Error(),
+			})
+			status := http.StatusInternalServerError
+			if errors.Is(err, alerting.ErrInvalidSilence) {
+				status = http.StatusBadRequest
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		writeJSON(w, http.StatusCreated, SilenceStatus{Silence: *created, Active: created.Active(now)})
+
+	case http.MethodDelete:
+		id := r.URL.Query().Get("id")
+		if id == "" {
+			http.Error(w, "id is required", http.StatusBadRequest)
+			return
+		}
+		expired, err := silences.Expire(id, now)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, SilenceStatus{Silence: *expired, Active: false})
+
+	default:
+		w.Header().Set("Allow", "GET, POST, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// writeJSON encodes a response body with the given status
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.GetInstance().Error("Failed to encode JSON response", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+}
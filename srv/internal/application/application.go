@@ -1,12 +1,17 @@
 package application
 
 import (
+	"encoding/json"
 	"net/http"
 	"sync"
 	"time"
 
+	"github.com/LissaiDev/Delphos/internal/alerting"
+	"github.com/LissaiDev/Delphos/internal/anomaly"
 	"github.com/LissaiDev/Delphos/internal/api"
 	"github.com/LissaiDev/Delphos/internal/config"
+	"github.com/LissaiDev/Delphos/internal/forecast"
+	"github.com/LissaiDev/Delphos/internal/history"
 	"github.com/LissaiDev/Delphos/internal/monitor"
 	"github.com/LissaiDev/Delphos/pkg/logger"
 )
@@ -15,6 +20,10 @@ import (
 type Application struct {
 	broker            *api.Broker
 	statsService      *monitor.StatsService
+	history           *history.Store
+	forecast          *forecast.Forecaster
+	anomaly           *anomaly.Detector
+	alerts            *alerting.Engine
 	logger            logger.BasicLogger
 	config            *config.Environment
 	middlewareFactory *api.MiddlewareFactory
@@ -32,6 +41,10 @@ func New() *Application {
 	return &Application{
 		broker:            api.GetInstance(),
 		statsService:      monitor.GetInstance(),
+		history:           history.GetInstance(),
+		forecast:          forecast.GetInstance(),
+		anomaly:           anomaly.GetInstance(),
+		alerts:            alerting.GetInstance(),
 		logger:            log,
 		config:            &config.Env,
 		middlewareFactory: api.NewMiddlewareFactory(log, rateLimitConfig),
@@ -42,6 +55,11 @@ func New() *Application {
 func (app *Application) Start() error {
 	app.broker.Start()
 	defer app.broker.Stop()
+	defer app.history.Close()
+	defer app.anomaly.Close()
+
+	// Forward alert state changes to SSE clients
+	app.alerts.Subscribe(app.broadcastAlert)
 
 	// Start background stats broadcasting
 	go app.startStatsBackgroundProcess()
@@ -53,17 +71,32 @@ func (app *Application) Start() error {
 	return app.startHTTPServer()
 }
 
-// startStatsBackgroundProcess handles periodic stats broadcasting
+// startStatsBackgroundProcess handles periodic stats broadcasting, anomaly scoring, history recording, forecasting and alert evaluation
+// Stats are collected on every tick while history or alerting is enabled, even without clients
 func (app *Application) startStatsBackgroundProcess() {
 	ticker := time.NewTicker(time.Duration(app.config.Interval) * time.Second)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		if len(app.broker.Clients) == 0 && !app.config.Background {
+	for at := range ticker.C {
+		if len(app.broker.Clients) == 0 && !app.config.Background && !app.history.Enabled() && !app.alerts.Enabled() {
+			continue
+		}
+
+		stats, err := app.statsService.GetStats()
+		if err != nil {
+			app.logger.Error("Failed to get stats", map[string]interface{}{
+				"error": err.Error(),
+			})
 			continue
 		}
 
-		data, err := app.statsService.GetStatsJSON()
+		samples := stats.Samples()
+		samples = append(samples, app.anomaly.Observe(at, samples)...)
+		app.history.Append(at, samples)
+		samples = append(samples, app.forecast.Observe(at, samples)...)
+		app.alerts.Evaluate(at, stats, samples)
+
+		data, err := json.Marshal(stats)
 		if err != nil {
 			app.logger.Error("Failed to get stats JSON", map[string]interface{}{
 				"error": err.Error(),
@@ -77,6 +110,22 @@ func (app *Application) startStatsBackgroundProcess() {
 	}
 }
 
+// broadcastAlert sends an alert state change to SSE clients as an "alert" event
+func (app *Application) broadcastAlert(event alerting.Event) {
+	if len(app.broker.Clients) == 0 {
+		return
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		app.logger.Error("Failed to get alert event JSON", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+	app.broker.BroadcastEvent("alert", string(data))
+}
+
 // setupRoutes configures HTTP routes with middleware chains
 func (app *Application) setupRoutes() {
 	// Create middleware chains using the factory and pure functions
@@ -86,7 +135,8 @@ func (app *Application) setupRoutes() {
 		Add(app.middlewareFactory.RateLimitMiddleware).
 		Add(app.middlewareFactory.LoggingMiddleware).
 		Add(app.middlewareFactory.ErrorLoggingMiddleware).
-		Add(app.middlewareFactory.MetricsMiddleware)
+		Add(app.middlewareFactory.MetricsMiddleware).
+		Add(api.TokenAuthMiddleware)
 
 	streamingChain := api.NewMiddlewareChain().
 		Add(api.StreamingSecurityMiddleware).
@@ -95,11 +145,29 @@ func (app *Application) setupRoutes() {
 
 	// Create handlers
 	statsHandler := apiChain.Apply(http.HandlerFunc(api.SystemStatsHandler))
+	processesHandler := apiChain.Apply(http.HandlerFunc(api.ProcessStatsHandler))
+	historyHandler := apiChain.Apply(http.HandlerFunc(api.HistoryHandler))
+	prometheusHandler := apiChain.Apply(http.HandlerFunc(api.PrometheusHandler))
+	notificationsHandler := apiChain.Apply(http.HandlerFunc(api.NotificationsHandler))
+	silencesHandler := apiChain.Apply(http.HandlerFunc(api.SilencesHandler))
+	alertsHandler := apiChain.Apply(http.HandlerFunc(api.AlertsHandler))
+	alertHistoryHandler := apiChain.Apply(http.HandlerFunc(api.AlertHistoryHandler))
+	ackHandler := apiChain.Apply(http.HandlerFunc(api.AckHandler))
+	forecastHandler := apiChain.Apply(http.HandlerFunc(api.ForecastHandler))
 	sseHandler := streamingChain.Apply(app.broker)
 
 	// Register routes
 	http.Handle("/api/stats", statsHandler)
 	http.Handle("/api/stats/sse", sseHandler)
+	http.Handle("/api/stats/processes", processesHandler)
+	http.Handle("/api/history", historyHandler)
+	http.Handle("/api/notifications", notificationsHandler)
+	http.Handle("/api/silences", silencesHandler)
+	http.Handle("/api/alerts", alertsHandler)
+	http.Handle("/api/alerts/history", alertHistoryHandler)
+	http.Handle("/api/alerts/ack", ackHandler)
+	http.Handle("/api/forecast", forecastHandler)
+	http.Handle("/metrics", prometheusHandler)
 }
 
 // startHTTPServer starts the HTTP server
@@ -11,10 +11,80 @@ type Environment struct {
 	CPUThreshold    float64 // CPU usage threshold in percentage
 	MemoryThreshold float64 // Memory usage threshold in percentage
 	DiskThreshold   float64 // Disk usage threshold in percentage
+	IOUtilThreshold float64 // Disk I/O utilization threshold in percentage
 	WebhookUrl      string  // Discord webhook URL for notifications
 	WebhookUsername string  // Username to use for Discord webhook notifications
+	SlackWebhookUrl string  // Slack incoming webhook URL for notifications
 	Background      bool    // If true, always checks stats in background (without broadcast)
-	Cooldown        int     // Cooldown period for notifications (in seconds)
+	Cooldown        int     // Repeat interval for alerts that keep firing (in seconds)
+	ProcessLimit    int     // Number of top processes included in each stats sample (0 includes all)
+	AlertProcesses  int     // Number of top processes listed in alert messages (0 disables)
+
+	CollectorTimeout   int      // Per-collector deadline in milliseconds
+	DisabledCollectors []string // Names of collectors that should not run (e.g. "processes")
+
+	HistoryRetention int // How long samples are kept in memory in seconds (0 disables history)
+	HistoryMemoryMB  int // Approximate memory budget for the in-memory history in megabytes
+
+	DataDir      string // Directory holding persisted metric segments (empty disables persistence)
+	RetentionRaw int    // How long full resolution samples are kept on disk in seconds
+	Retention1m  int    // How long 1 minute rollups are kept on disk in seconds
+	Retention1h  int    // How long 1 hour rollups are kept on disk in seconds
+
+	AlertingFile      string // JSON file with alert rules (empty uses rules built from the thresholds)
+	AlertLogRetention int    // How long alert state changes are kept in the alert log in seconds
+	APIToken          string // Bearer token required by API requests that change state (empty rejects them)
+
+	ForecastWindow  int // Seconds of usage history fitted to forecast disk, inode and swap exhaustion
+	ForecastHorizon int // Forecast exhaustion within this many seconds raises the default alerts (0 disables them)
+
+	AnomalyMetrics      []string // Metrics tracked for anomalies (empty disables anomaly detection)
+	AnomalyHalfLife     int      // Seconds after which a sample weighs half as much in the baselines
+	AnomalySeasonal     bool     // If true, keeps a baseline per hour of the day
+	AnomalyHourHalfLife int      // Seconds after which a sample weighs half as much in its hour of the day baseline
+	AnomalyThreshold    float64  // Anomaly score (absolute z-score) raising the default anomaly alert
+	AnomalyFor          int      // Seconds the score must stay above the threshold before the alert fires
+
+	AlertWebhookUrl      string            // Generic JSON webhook receiving alerts (empty disables it)
+	AlertWebhookSecret   string            // Secret for the HMAC-SHA256 request signature (empty sends unsigned requests)
+	AlertWebhookHeaders  map[string]string // Extra headers sent with every webhook request
+	AlertWebhookTemplate string            // text/template file rendering the request body (empty sends the default payload)
+
+	SMTPHost         string   // SMTP server host for email notifications (empty disables email)
+	SMTPPort         int      // SMTP server port
+	SMTPUsername     string   // SMTP username (empty skips authentication)
+	SMTPPassword     string   // SMTP password
+	SMTPAuth         string   // Authentication mechanism: "plain" or "login"
+	SMTPTLS          string   // Transport security: "starttls", "tls" (implicit) or "none"
+	SMTPFrom         string   // Sender address
+	SMTPTo           []string // Recipient addresses
+	SMTPTextTemplate string   // text/template file for the plain text body (empty uses the built-in one)
+	SMTPHTMLTemplate string   // html/template file for the HTML body (empty uses the built-in one)
+	SMTPBatchWindow  int      // Seconds alerts are collected into one email by the notification queue (0 sends each alert on its own)
+
+	PagerDutyRoutingKey string // PagerDuty Events API v2 integration key (empty disables PagerDuty)
+	OpsgenieApiKey      string // Opsgenie API integration key (empty disables Opsgenie)
+	OpsgenieApiUrl      string // Opsgenie API base URL (e.g. https://api.eu.opsgenie.com)
+
+	TelegramBotToken string   // Telegram bot token (empty disables Telegram)
+	TelegramChatID   string   // Telegram chat receiving alerts
+	TelegramApiUrl   string   // Telegram Bot API base URL
+	NtfyUrl          string   // ntfy server base URL
+	NtfyTopic        string   // ntfy topic receiving alerts (empty disables ntfy)
+	NtfyToken        string   // ntfy access token (empty publishes anonymously)
+	NtfyTags         []string // Extra ntfy tags added to every message
+	GotifyUrl        string   // Gotify server base URL (empty disables Gotify)
+	GotifyToken      string   // Gotify application token
+
+	ExecCommand     string   // Program run for every alert (empty disables the exec handler)
+	ExecArgs        []string // Arguments passed to the program
+	ExecTimeout     int      // Seconds a run may take before it is killed
+	ExecConcurrency int      // Maximum number of runs at the same time
+
+	NotifyWorkers      int // Delivery goroutines per notification handler
+	NotifyQueueSize    int // Notifications queued per handler before the oldest is dropped
+	NotifyMaxAttempts  int // Delivery attempts before a notification is dropped
+	NotifyRetryBackoff int // Seconds before the first retry, doubled on every further failure
 }
 
 // Configuration errors
@@ -24,9 +94,54 @@ var (
 	ErrInvalidName            = errors.New("invalid name configuration")
 	ErrInvalidWebhookUrl      = errors.New("invalid webhook url configuration")
 	ErrInvalidWebhookUsername = errors.New("invalid webhook username configuration")
+	ErrInvalidSlackWebhookUrl = errors.New("invalid slack webhook url configuration")
 	ErrInvalidCPUThreshold    = errors.New("invalid cpu threshold configuration")
 	ErrInvalidMemoryThreshold = errors.New("invalid memory threshold configuration")
 	ErrInvalidDiskThreshold   = errors.New("invalid disk threshold configuration")
+	ErrInvalidIOUtilThreshold = errors.New("invalid io utilization threshold configuration")
 	ErrInvalidBackground      = errors.New("invalid background configuration")
 	ErrInvalidCooldown        = errors.New("invalid cooldown configuration")
+	ErrInvalidProcessLimit    = errors.New("invalid process limit configuration")
+	ErrInvalidAlertProcesses  = errors.New("invalid alert processes configuration")
+
+	ErrInvalidCollectorTimeout = errors.New("invalid collector timeout configuration")
+
+	ErrInvalidHistoryRetention = errors.New("invalid history retention configuration")
+	ErrInvalidHistoryMemoryMB  = errors.New("invalid history memory budget configuration")
+
+	ErrInvalidRetentionRaw = errors.New("invalid raw retention configuration")
+	ErrInvalidRetention1m  = errors.New("invalid 1m retention configuration")
+	ErrInvalidRetention1h  = errors.New("invalid 1h retention configuration")
+
+	ErrInvalidAlertLogRetention = errors.New("invalid alert log retention configuration")
+
+	ErrInvalidForecastWindow  = errors.New("invalid forecast window configuration")
+	ErrInvalidForecastHorizon = errors.New("invalid forecast horizon configuration")
+
+	ErrInvalidAnomalyHalfLife     = errors.New("invalid anomaly half-life configuration")
+	ErrInvalidAnomalyHourHalfLife = errors.New("invalid anomaly hour half-life configuration")
+	ErrInvalidAnomalyThreshold    = errors.New("invalid anomaly threshold configuration")
+	ErrInvalidAnomalyFor          = errors.New("invalid anomaly for configuration")
+
+	ErrInvalidAlertWebhookUrl = errors.New("invalid alert webhook url configuration")
+
+	ErrInvalidSMTPPort        = errors.New("invalid smtp port configuration")
+	ErrInvalidSMTPAuth        = errors.New("invalid smtp auth configuration")
+	ErrInvalidSMTPTLS         = errors.New("invalid smtp tls configuration")
+	ErrInvalidSMTPAddresses   = errors.New("invalid smtp sender or recipients configuration")
+	ErrInvalidSMTPBatchWindow = errors.New("invalid smtp batch window configuration")
+
+	ErrInvalidOpsgenieApiUrl = errors.New("invalid opsgenie api url configuration")
+
+	ErrInvalidTelegramApiUrl = errors.New("invalid telegram api url configuration")
+	ErrInvalidNtfyUrl        = errors.New("invalid ntfy url configuration")
+	ErrInvalidGotifyUrl      = errors.New("invalid gotify url configuration")
+
+	ErrInvalidExecTimeout     = errors.New("invalid exec timeout configuration")
+	ErrInvalidExecConcurrency = errors.New("invalid exec concurrency configuration")
+
+	ErrInvalidNotifyWorkers      = errors.New("invalid notify workers configuration")
+	ErrInvalidNotifyQueueSize    = errors.New("invalid notify queue size configuration")
+	ErrInvalidNotifyMaxAttempts  = errors.New("invalid notify max attempts configuration")
+	ErrInvalidNotifyRetryBackoff = errors.New("invalid notify retry backoff configuration")
 )
@@ -1,8 +1,11 @@
 package config
 
 import (
+	"net/mail"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -80,13 +83,71 @@ func (s *Service) setDefaults() {
 	s.env.Name = "Delphos Server API"
 	s.env.Port = ":8080"
 	s.env.Interval = 5
+	s.env.CollectorTimeout = 3000
 	s.env.CPUThreshold = 80.0
 	s.env.MemoryThreshold = 90.0
 	s.env.DiskThreshold = 90.0
+	s.env.IOUtilThreshold = 90.0
 	s.env.WebhookUrl = ""
 	s.env.WebhookUsername = ""
+	s.env.SlackWebhookUrl = ""
 	s.env.Background = false
 	s.env.Cooldown = 30
+	s.env.ProcessLimit = 10
+	s.env.AlertProcesses = 3
+	s.env.DisabledCollectors = nil
+	s.env.HistoryRetention = 21600
+	s.env.HistoryMemoryMB = 64
+	s.env.DataDir = "data"
+	s.env.RetentionRaw = 86400
+	s.env.Retention1m = 2592000
+	s.env.Retention1h = 31536000
+	s.env.AlertingFile = ""
+	s.env.AlertLogRetention = 2592000
+	s.env.APIToken = ""
+	s.env.ForecastWindow = 21600
+	s.env.ForecastHorizon = 86400
+	s.env.AnomalyMetrics = nil
+	s.env.AnomalyHalfLife = 3600
+	s.env.AnomalySeasonal = false
+	s.env.AnomalyHourHalfLife = 604800
+	s.env.AnomalyThreshold = 3
+	s.env.AnomalyFor = 300
+	s.env.AlertWebhookUrl = ""
+	s.env.AlertWebhookSecret = ""
+	s.env.AlertWebhookHeaders = nil
+	s.env.AlertWebhookTemplate = ""
+	s.env.SMTPHost = ""
+	s.env.SMTPPort = 587
+	s.env.SMTPUsername = ""
+	s.env.SMTPPassword = ""
+	s.env.SMTPAuth = "plain"
+	s.env.SMTPTLS = "starttls"
+	s.env.SMTPFrom = ""
+	s.env.SMTPTo = nil
+	s.env.SMTPTextTemplate = ""
+	s.env.SMTPHTMLTemplate = ""
+	s.env.SMTPBatchWindow = 10
+	s.env.PagerDutyRoutingKey = ""
+	s.env.OpsgenieApiKey = ""
+	s.env.OpsgenieApiUrl = "https://api.opsgenie.com"
+	s.env.TelegramBotToken = ""
+	s.env.TelegramChatID = ""
+	s.env.TelegramApiUrl = "https://api.telegram.org"
+	s.env.NtfyUrl = "https://ntfy.sh"
+	s.env.NtfyTopic = ""
+	s.env.NtfyToken = ""
+	s.env.NtfyTags = nil
+	s.env.GotifyUrl = ""
+	s.env.GotifyToken = ""
+	s.env.ExecCommand = ""
+	s.env.ExecArgs = nil
+	s.env.ExecTimeout = 30
+	s.env.ExecConcurrency = 4
+	s.env.NotifyWorkers = 1
+	s.env.NotifyQueueSize = 1000
+	s.env.NotifyMaxAttempts = 5
+	s.env.NotifyRetryBackoff = 30
 }
 
 // loadDotEnv attempts to load .env file
@@ -103,25 +164,141 @@ func (s *Service) loadFromEnv() error {
 	name, nameExists := os.LookupEnv("NAME")
 	port, portExists := os.LookupEnv("PORT")
 	intervalStr, intervalExists := os.LookupEnv("INTERVAL")
+	collectorTimeoutStr, collectorTimeoutExists := os.LookupEnv("COLLECTOR_TIMEOUT")
 	cpuThresholdStr, cpuThresholdExists := os.LookupEnv("CPU_THRESHOLD")
 	memoryThresholdStr, memoryThresholdExists := os.LookupEnv("MEMORY_THRESHOLD")
 	diskThresholdStr, diskThresholdExists := os.LookupEnv("DISK_THRESHOLD")
+	ioUtilThresholdStr, ioUtilThresholdExists := os.LookupEnv("IO_UTIL_THRESHOLD")
 	webhookUrl, webhookUrlExists := os.LookupEnv("WEBHOOK_URL")
 	webhookUsername, webhookUsernameExists := os.LookupEnv("WEBHOOK_USERNAME")
+	slackWebhookUrl, slackWebhookUrlExists := os.LookupEnv("SLACK_WEBHOOK_URL")
 	backgroundStr, backgroundExists := os.LookupEnv("BACKGROUND")
 	cooldownStr, cooldownExists := os.LookupEnv("COOLDOWN")
+	processLimitStr, processLimitExists := os.LookupEnv("PROCESS_LIMIT")
+	alertProcessesStr, alertProcessesExists := os.LookupEnv("ALERT_PROCESSES")
+	disabledCollectorsStr, disabledCollectorsExists := os.LookupEnv("DISABLED_COLLECTORS")
+	historyRetentionStr, historyRetentionExists := os.LookupEnv("HISTORY_RETENTION")
+	historyMemoryStr, historyMemoryExists := os.LookupEnv("HISTORY_MEMORY_MB")
+	dataDir, dataDirExists := os.LookupEnv("DATA_DIR")
+	retentionRawStr, retentionRawExists := os.LookupEnv("RETENTION_RAW")
+	retention1mStr, retention1mExists := os.LookupEnv("RETENTION_1M")
+	retention1hStr, retention1hExists := os.LookupEnv("RETENTION_1H")
+	alertingFile, alertingFileExists := os.LookupEnv("ALERTING_FILE")
+	alertLogRetentionStr, alertLogRetentionExists := os.LookupEnv("ALERT_LOG_RETENTION")
+	apiToken, apiTokenExists := os.LookupEnv("API_TOKEN")
+	forecastWindowStr, forecastWindowExists := os.LookupEnv("FORECAST_WINDOW")
+	forecastHorizonStr, forecastHorizonExists := os.LookupEnv("FORECAST_HORIZON")
+	anomalyMetricsStr, anomalyMetricsExists := os.LookupEnv("ANOMALY_METRICS")
+	anomalyHalfLifeStr, anomalyHalfLifeExists := os.LookupEnv("ANOMALY_HALF_LIFE")
+	anomalySeasonalStr, anomalySeasonalExists := os.LookupEnv("ANOMALY_SEASONAL")
+	anomalyHourHalfLifeStr, anomalyHourHalfLifeExists := os.LookupEnv("ANOMALY_HOUR_HALF_LIFE")
+	anomalyThresholdStr, anomalyThresholdExists := os.LookupEnv("ANOMALY_THRESHOLD")
+	anomalyForStr, anomalyForExists := os.LookupEnv("ANOMALY_FOR")
+	alertWebhookUrl, alertWebhookUrlExists := os.LookupEnv("ALERT_WEBHOOK_URL")
+	alertWebhookSecret, alertWebhookSecretExists := os.LookupEnv("ALERT_WEBHOOK_SECRET")
+	alertWebhookHeadersStr, alertWebhookHeadersExists := os.LookupEnv("ALERT_WEBHOOK_HEADERS")
+	alertWebhookTemplate, alertWebhookTemplateExists := os.LookupEnv("ALERT_WEBHOOK_TEMPLATE")
+	smtpHost, smtpHostExists := os.LookupEnv("SMTP_HOST")
+	smtpPortStr, smtpPortExists := os.LookupEnv("SMTP_PORT")
+	smtpUsername, smtpUsernameExists := os.LookupEnv("SMTP_USERNAME")
+	smtpPassword, smtpPasswordExists := os.LookupEnv("SMTP_PASSWORD")
+	smtpAuth, smtpAuthExists := os.LookupEnv("SMTP_AUTH")
+	smtpTLS, smtpTLSExists := os.LookupEnv("SMTP_TLS")
+	smtpFrom, smtpFromExists := os.LookupEnv("SMTP_FROM")
+	smtpToStr, smtpToExists := os.LookupEnv("SMTP_TO")
+	smtpTextTemplate, smtpTextTemplateExists := os.LookupEnv("SMTP_TEXT_TEMPLATE")
+	smtpHTMLTemplate, smtpHTMLTemplateExists := os.LookupEnv("SMTP_HTML_TEMPLATE")
+	smtpBatchWindowStr, smtpBatchWindowExists := os.LookupEnv("SMTP_BATCH_WINDOW")
+	pagerDutyRoutingKey, pagerDutyRoutingKeyExists := os.LookupEnv("PAGERDUTY_ROUTING_KEY")
+	opsgenieApiKey, opsgenieApiKeyExists := os.LookupEnv("OPSGENIE_API_KEY")
+	opsgenieApiUrl, opsgenieApiUrlExists := os.LookupEnv("OPSGENIE_API_URL")
+	telegramBotToken, telegramBotTokenExists := os.LookupEnv("TELEGRAM_BOT_TOKEN")
+	telegramChatID, telegramChatIDExists := os.LookupEnv("TELEGRAM_CHAT_ID")
+	telegramApiUrl, telegramApiUrlExists := os.LookupEnv("TELEGRAM_API_URL")
+	ntfyUrl, ntfyUrlExists := os.LookupEnv("NTFY_URL")
+	ntfyTopic, ntfyTopicExists := os.LookupEnv("NTFY_TOPIC")
+	ntfyToken, ntfyTokenExists := os.LookupEnv("NTFY_TOKEN")
+	ntfyTagsStr, ntfyTagsExists := os.LookupEnv("NTFY_TAGS")
+	gotifyUrl, gotifyUrlExists := os.LookupEnv("GOTIFY_URL")
+	gotifyToken, gotifyTokenExists := os.LookupEnv("GOTIFY_TOKEN")
+	execCommand, execCommandExists := os.LookupEnv("EXEC_COMMAND")
+	execArgsStr, execArgsExists := os.LookupEnv("EXEC_ARGS")
+	execTimeoutStr, execTimeoutExists := os.LookupEnv("EXEC_TIMEOUT")
+	execConcurrencyStr, execConcurrencyExists := os.LookupEnv("EXEC_CONCURRENCY")
+	notifyWorkersStr, notifyWorkersExists := os.LookupEnv("NOTIFY_WORKERS")
+	notifyQueueSizeStr, notifyQueueSizeExists := os.LookupEnv("NOTIFY_QUEUE_SIZE")
+	notifyMaxAttemptsStr, notifyMaxAttemptsExists := os.LookupEnv("NOTIFY_MAX_ATTEMPTS")
+	notifyRetryBackoffStr, notifyRetryBackoffExists := os.LookupEnv("NOTIFY_RETRY_BACKOFF")
 
 	s.logger.Debug("Environment variables status", map[string]interface{}{
-		"NAME_exists":             nameExists,
-		"PORT_exists":             portExists,
-		"INTERVAL_exists":         intervalExists,
-		"CPU_THRESHOLD_exists":    cpuThresholdExists,
-		"MEMORY_THRESHOLD_exists": memoryThresholdExists,
-		"DISK_THRESHOLD_exists":   diskThresholdExists,
-		"WEBHOOK_URL_exists":      webhookUrlExists,
-		"WEBHOOK_USERNAME_exists": webhookUsernameExists,
-		"BACKGROUND_exists":       backgroundExists,
-		"COOLDOWN_exists":         cooldownExists,
+		"NAME_exists":                   nameExists,
+		"PORT_exists":                   portExists,
+		"INTERVAL_exists":               intervalExists,
+		"COLLECTOR_TIMEOUT_exists":      collectorTimeoutExists,
+		"CPU_THRESHOLD_exists":          cpuThresholdExists,
+		"MEMORY_THRESHOLD_exists":       memoryThresholdExists,
+		"DISK_THRESHOLD_exists":         diskThresholdExists,
+		"IO_UTIL_THRESHOLD_exists":      ioUtilThresholdExists,
+		"WEBHOOK_URL_exists":            webhookUrlExists,
+		"WEBHOOK_USERNAME_exists":       webhookUsernameExists,
+		"SLACK_WEBHOOK_URL_exists":      slackWebhookUrlExists,
+		"BACKGROUND_exists":             backgroundExists,
+		"COOLDOWN_exists":               cooldownExists,
+		"PROCESS_LIMIT_exists":          processLimitExists,
+		"ALERT_PROCESSES_exists":        alertProcessesExists,
+		"DISABLED_COLLECTORS_exists":    disabledCollectorsExists,
+		"HISTORY_RETENTION_exists":      historyRetentionExists,
+		"HISTORY_MEMORY_MB_exists":      historyMemoryExists,
+		"DATA_DIR_exists":               dataDirExists,
+		"RETENTION_RAW_exists":          retentionRawExists,
+		"RETENTION_1M_exists":           retention1mExists,
+		"RETENTION_1H_exists":           retention1hExists,
+		"ALERTING_FILE_exists":          alertingFileExists,
+		"ALERT_LOG_RETENTION_exists":    alertLogRetentionExists,
+		"API_TOKEN_exists":              apiTokenExists,
+		"FORECAST_WINDOW_exists":        forecastWindowExists,
+		"FORECAST_HORIZON_exists":       forecastHorizonExists,
+		"ANOMALY_METRICS_exists":        anomalyMetricsExists,
+		"ANOMALY_HALF_LIFE_exists":      anomalyHalfLifeExists,
+		"ANOMALY_SEASONAL_exists":       anomalySeasonalExists,
+		"ANOMALY_HOUR_HALF_LIFE_exists": anomalyHourHalfLifeExists,
+		"ANOMALY_THRESHOLD_exists":      anomalyThresholdExists,
+		"ANOMALY_FOR_exists":            anomalyForExists,
+		"ALERT_WEBHOOK_URL_exists":      alertWebhookUrlExists,
+		"ALERT_WEBHOOK_SECRET_exists":   alertWebhookSecretExists,
+		"ALERT_WEBHOOK_HEADERS_exists":  alertWebhookHeadersExists,
+		"ALERT_WEBHOOK_TEMPLATE_exists": alertWebhookTemplateExists,
+		"SMTP_HOST_exists":              smtpHostExists,
+		"SMTP_PORT_exists":              smtpPortExists,
+		"SMTP_USERNAME_exists":          smtpUsernameExists,
+		"SMTP_PASSWORD_exists":          smtpPasswordExists,
+		"SMTP_AUTH_exists":              smtpAuthExists,
+		"SMTP_TLS_exists":               smtpTLSExists,
+		"SMTP_FROM_exists":              smtpFromExists,
+		"SMTP_TO_exists":                smtpToExists,
+		"SMTP_TEXT_TEMPLATE_exists":     smtpTextTemplateExists,
+		"SMTP_HTML_TEMPLATE_exists":     smtpHTMLTemplateExists,
+		"SMTP_BATCH_WINDOW_exists":      smtpBatchWindowExists,
+		"PAGERDUTY_ROUTING_KEY_exists":  pagerDutyRoutingKeyExists,
+		"OPSGENIE_API_KEY_exists":       opsgenieApiKeyExists,
+		"OPSGENIE_API_URL_exists":       opsgenieApiUrlExists,
+		"TELEGRAM_BOT_TOKEN_exists":     telegramBotTokenExists,
+		"TELEGRAM_CHAT_ID_exists":       telegramChatIDExists,
+		"TELEGRAM_API_URL_exists":       telegramApiUrlExists,
+		"NTFY_URL_exists":               ntfyUrlExists,
+		"NTFY_TOPIC_exists":             ntfyTopicExists,
+		"NTFY_TOKEN_exists":             ntfyTokenExists,
+		"NTFY_TAGS_exists":              ntfyTagsExists,
+		"GOTIFY_URL_exists":             gotifyUrlExists,
+		"GOTIFY_TOKEN_exists":           gotifyTokenExists,
+		"EXEC_COMMAND_exists":           execCommandExists,
+		"EXEC_ARGS_exists":              execArgsExists,
+		"EXEC_TIMEOUT_exists":           execTimeoutExists,
+		"EXEC_CONCURRENCY_exists":       execConcurrencyExists,
+		"NOTIFY_WORKERS_exists":         notifyWorkersExists,
+		"NOTIFY_QUEUE_SIZE_exists":      notifyQueueSizeExists,
+		"NOTIFY_MAX_ATTEMPTS_exists":    notifyMaxAttemptsExists,
+		"NOTIFY_RETRY_BACKOFF_exists":   notifyRetryBackoffExists,
 	})
 
 	// Load values if they exist
@@ -145,6 +322,18 @@ func (s *Service) loadFromEnv() error {
 		}
 	}
 
+	if collectorTimeoutExists {
+		if v, err := strconv.Atoi(collectorTimeoutStr); err == nil {
+			s.env.CollectorTimeout = v
+		} else {
+			s.logger.Warn("Failed to parse COLLECTOR_TIMEOUT environment variable, using default", map[string]interface{}{
+				"value":   collectorTimeoutStr,
+				"error":   err.Error(),
+				"default": s.env.CollectorTimeout,
+			})
+		}
+	}
+
 	if cpuThresholdExists {
 		if v, err := strconv.ParseFloat(cpuThresholdStr, 64); err == nil {
 			s.env.CPUThreshold = v
@@ -181,12 +370,27 @@ func (s *Service) loadFromEnv() error {
 		}
 	}
 
+	if ioUtilThresholdExists {
+		if v, err := strconv.ParseFloat(ioUtilThresholdStr, 64); err == nil {
+			s.env.IOUtilThreshold = v
+		} else {
+			s.logger.Warn("Failed to parse IO_UTIL_THRESHOLD environment variable, using default", map[string]interface{}{
+				"value":   ioUtilThresholdStr,
+				"error":   err.Error(),
+				"default": s.env.IOUtilThreshold,
+			})
+		}
+	}
+
 	if webhookUrlExists {
 		s.env.WebhookUrl = webhookUrl
 	}
 	if webhookUsernameExists {
 		s.env.WebhookUsername = webhookUsername
 	}
+	if slackWebhookUrlExists {
+		s.env.SlackWebhookUrl = slackWebhookUrl
+	}
 	if backgroundExists {
 		if v, err := strconv.ParseBool(backgroundStr); err == nil {
 			s.env.Background = v
@@ -209,18 +413,427 @@ func (s *Service) loadFromEnv() error {
 			})
 		}
 	}
+	if processLimitExists {
+		if v, err := strconv.Atoi(processLimitStr); err == nil {
+			s.env.ProcessLimit = v
+		} else {
+			s.logger.Warn("Failed to parse PROCESS_LIMIT environment variable, using default", map[string]interface{}{
+				"value":   processLimitStr,
+				"error":   err.Error(),
+				"default": s.env.ProcessLimit,
+			})
+		}
+	}
+	if alertProcessesExists {
+		if v, err := strconv.Atoi(alertProcessesStr); err == nil {
+			s.env.AlertProcesses = v
+		} else {
+			s.logger.Warn("Failed to parse ALERT_PROCESSES environment variable, using default", map[string]interface{}{
+				"value":   alertProcessesStr,
+				"error":   err.Error(),
+				"default": s.env.AlertProcesses,
+			})
+		}
+	}
+	if disabledCollectorsExists {
+		s.env.DisabledCollectors = splitList(disabledCollectorsStr)
+	}
+	if historyRetentionExists {
+		if v, err := strconv.Atoi(historyRetentionStr); err == nil {
+			s.env.HistoryRetention = v
+		} else {
+			s.logger.Warn("Failed to parse HISTORY_RETENTION environment variable, using default", map[string]interface{}{
+				"value":   historyRetentionStr,
+				"error":   err.Error(),
+				"default": s.env.HistoryRetention,
+			})
+		}
+	}
+	if historyMemoryExists {
+		if v, err := strconv.Atoi(historyMemoryStr); err == nil {
+			s.env.HistoryMemoryMB = v
+		} else {
+			s.logger.Warn("Failed to parse HISTORY_MEMORY_MB environment variable, using default", map[string]interface{}{
+				"value":   historyMemoryStr,
+				"error":   err.Error(),
+				"default": s.env.HistoryMemoryMB,
+			})
+		}
+	}
+	if dataDirExists {
+		s.env.DataDir = dataDir
+	}
+	if retentionRawExists {
+		if v, err := strconv.Atoi(retentionRawStr); err == nil {
+			s.env.RetentionRaw = v
+		} else {
+			s.logger.Warn("Failed to parse RETENTION_RAW environment variable, using default", map[string]interface{}{
+				"value":   retentionRawStr,
+				"error":   err.Error(),
+				"default": s.env.RetentionRaw,
+			})
+		}
+	}
+	if retention1mExists {
+		if v, err := strconv.Atoi(retention1mStr); err == nil {
+			s.env.Retention1m = v
+		} else {
+			s.logger.Warn("Failed to parse RETENTION_1M environment variable, using default", map[string]interface{}{
+				"value":   retention1mStr,
+				"error":   err.Error(),
+				"default": s.env.Retention1m,
+			})
+		}
+	}
+	if retention1hExists {
+		if v, err := strconv.Atoi(retention1hStr); err == nil {
+			s.env.Retention1h = v
+		} else {
+			s.logger.Warn("Failed to parse RETENTION_1H environment variable, using default", map[string]interface{}{
+				"value":   retention1hStr,
+				"error":   err.Error(),
+				"default": s.env.Retention1h,
+			})
+		}
+	}
+	if alertingFileExists {
+		s.env.AlertingFile = alertingFile
+	}
+	if apiTokenExists {
+		s.env.APIToken = apiToken
+	}
+	if alertLogRetentionExists {
+		if v, err := strconv.Atoi(alertLogRetentionStr); err == nil {
+			s.env.AlertLogRetention = v
+		} else {
+			s.logger.Warn("Failed to parse ALERT_LOG_RETENTION environment variable, using default", map[string]interface{}{
+				"value":   alertLogRetentionStr,
+				"error":   err.Error(),
+				"default": s.env.AlertLogRetention,
+			})
+		}
+	}
+	if forecastWindowExists {
+		if v, err := strconv.Atoi(forecastWindowStr); err == nil {
+			s.env.ForecastWindow = v
+		} else {
+			s.logger.Warn("Failed to parse FORECAST_WINDOW environment variable, using default", map[string]interface{}{
+				"value":   forecastWindowStr,
+				"error":   err.Error(),
+				"default": s.env.ForecastWindow,
+			})
+		}
+	}
+	if forecastHorizonExists {
+		if v, err := strconv.Atoi(forecastHorizonStr); err == nil {
+			s.env.ForecastHorizon = v
+		} else {
+			s.logger.Warn("Failed to parse FORECAST_HORIZON environment variable, using default", map[string]interface{}{
+				"value":   forecastHorizonStr,
+				"error":   err.Error(),
+				"default": s.env.ForecastHorizon,
+			})
+		}
+	}
+	if anomalyMetricsExists {
+		s.env.AnomalyMetrics = splitList(anomalyMetricsStr)
+	}
+	if anomalyHalfLifeExists {
+		if v, err := strconv.Atoi(anomalyHalfLifeStr); err == nil {
+			s.env.AnomalyHalfLife = v
+		} else {
+			s.logger.Warn("Failed to parse ANOMALY_HALF_LIFE environment variable, using default", map[string]interface{}{
+				"value":   anomalyHalfLifeStr,
+				"error":   err.Error(),
+				"default": s.env.AnomalyHalfLife,
+			})
+		}
+	}
+	if anomalySeasonalExists {
+		if v, err := strconv.ParseBool(anomalySeasonalStr); err == nil {
+			s.env.AnomalySeasonal = v
+		} else {
+			s.logger.Warn("Failed to parse ANOMALY_SEASONAL environment variable, using default", map[string]interface{}{
+				"value":   anomalySeasonalStr,
+				"error":   err.Error(),
+				"default": s.env.AnomalySeasonal,
+			})
+		}
+	}
+	if anomalyHourHalfLifeExists {
+		if v, err := strconv.Atoi(anomalyHourHalfLifeStr); err == nil {
+			s.env.AnomalyHourHalfLife = v
+		} else {
+			s.logger.Warn("Failed to parse ANOMALY_HOUR_HALF_LIFE environment variable, using default", map[string]interface{}{
+				"value":   anomalyHourHalfLifeStr,
+				"error":   err.Error(),
+				"default": s.env.AnomalyHourHalfLife,
+			})
+		}
+	}
+	if anomalyThresholdExists {
+		if v, err := strconv.ParseFloat(anomalyThresholdStr, 64); err == nil {
+			s.env.AnomalyThreshold = v
+		} else {
+			s.logger.Warn("Failed to parse ANOMALY_THRESHOLD environment variable, using default", map[string]interface{}{
+				"value":   anomalyThresholdStr,
+				"error":   err.Error(),
+				"default": s.env.AnomalyThreshold,
+			})
+		}
+	}
+	if anomalyForExists {
+		if v, err := strconv.Atoi(anomalyForStr); err == nil {
+			s.env.AnomalyFor = v
+		} else {
+			s.logger.Warn("Failed to parse ANOMALY_FOR environment variable, using default", map[string]interface{}{
+				"value":   anomalyForStr,
+				"error":   err.Error(),
+				"default": s.env.AnomalyFor,
+			})
+		}
+	}
+	if alertWebhookUrlExists {
+		s.env.AlertWebhookUrl = alertWebhookUrl
+	}
+	if alertWebhookSecretExists {
+		s.env.AlertWebhookSecret = alertWebhookSecret
+	}
+	if alertWebhookHeadersExists {
+		headers, invalid := parseHeaders(alertWebhookHeadersStr)
+		for _, entry := range invalid {
+			s.logger.Warn("Ignoring malformed ALERT_WEBHOOK_HEADERS entry, expected \"Name: value\"", map[string]interface{}{
+				"value": entry,
+			})
+		}
+		s.env.AlertWebhookHeaders = headers
+	}
+	if alertWebhookTemplateExists {
+		s.env.AlertWebhookTemplate = alertWebhookTemplate
+	}
+	if smtpHostExists {
+		s.env.SMTPHost = smtpHost
+	}
+	if smtpPortExists {
+		if v, err := strconv.Atoi(smtpPortStr); err == nil {
+			s.env.SMTPPort = v
+		} else {
+			s.logger.Warn("Failed to parse SMTP_PORT environment variable, using default", map[string]interface{}{
+				"value":   smtpPortStr,
+				"error":   err.Error(),
+				"default": s.env.SMTPPort,
+			})
+		}
+	}
+	if smtpUsernameExists {
+		s.env.SMTPUsername = smtpUsername
+	}
+	if smtpPasswordExists {
+		s.env.SMTPPassword = smtpPassword
+	}
+	if smtpAuthExists {
+		s.env.SMTPAuth = strings.ToLower(strings.TrimSpace(smtpAuth))
+	}
+	if smtpTLSExists {
+		s.env.SMTPTLS = strings.ToLower(strings.TrimSpace(smtpTLS))
+	}
+	if smtpFromExists {
+		s.env.SMTPFrom = smtpFrom
+	}
+	if smtpToExists {
+		s.env.SMTPTo = splitList(smtpToStr)
+	}
+	if smtpTextTemplateExists {
+		s.env.SMTPTextTemplate = smtpTextTemplate
+	}
+	if smtpHTMLTemplateExists {
+		s.env.SMTPHTMLTemplate = smtpHTMLTemplate
+	}
+	if smtpBatchWindowExists {
+		if v, err := strconv.Atoi(smtpBatchWindowStr); err == nil {
+			s.env.SMTPBatchWindow = v
+		} else {
+			s.logger.Warn("Failed to parse SMTP_BATCH_WINDOW environment variable, using default", map[string]interface{}{
+				"value":   smtpBatchWindowStr,
+				"error":   err.Error(),
+				"default": s.env.SMTPBatchWindow,
+			})
+		}
+	}
+	if pagerDutyRoutingKeyExists {
+		s.env.PagerDutyRoutingKey = pagerDutyRoutingKey
+	}
+	if opsgenieApiKeyExists {
+		s.env.OpsgenieApiKey = opsgenieApiKey
+	}
+	if opsgenieApiUrlExists {
+		s.env.OpsgenieApiUrl = opsgenieApiUrl
+	}
+	if telegramBotTokenExists {
+		s.env.TelegramBotToken = telegramBotToken
+	}
+	if telegramChatIDExists {
+		s.env.TelegramChatID = telegramChatID
+	}
+	if telegramApiUrlExists {
+		s.env.TelegramApiUrl = telegramApiUrl
+	}
+	if ntfyUrlExists {
+		s.env.NtfyUrl = ntfyUrl
+	}
+	if ntfyTopicExists {
+		s.env.NtfyTopic = ntfyTopic
+	}
+	if ntfyTokenExists {
+		s.env.NtfyToken = ntfyToken
+	}
+	if ntfyTagsExists {
+		s.env.NtfyTags = splitList(ntfyTagsStr)
+	}
+	if gotifyUrlExists {
+		s.env.GotifyUrl = gotifyUrl
+	}
+	if gotifyTokenExists {
+		s.env.GotifyToken = gotifyToken
+	}
+	if execCommandExists {
+		s.env.ExecCommand = execCommand
+	}
+	if execArgsExists {
+		s.env.ExecArgs = splitList(execArgsStr)
+	}
+	if execTimeoutExists {
+		if v, err := strconv.Atoi(execTimeoutStr); err == nil {
+			s.env.ExecTimeout = v
+		} else {
+			s.logger.Warn("Failed to parse EXEC_TIMEOUT environment variable, using default", map[string]interface{}{
+				"value":   execTimeoutStr,
+				"error":   err.Error(),
+				"default": s.env.ExecTimeout,
+			})
+		}
+	}
+	if execConcurrencyExists {
+		if v, err := strconv.Atoi(execConcurrencyStr); err == nil {
+			s.env.ExecConcurrency = v
+		} else {
+			s.logger.Warn("Failed to parse EXEC_CONCURRENCY environment variable, using default", map[string]interface{}{
+				"value":   execConcurrencyStr,
+				"error":   err.Error(),
+				"default": s.env.ExecConcurrency,
+			})
+		}
+	}
+	if notifyWorkersExists {
+		if v, err := strconv.Atoi(notifyWorkersStr); err == nil {
+			s.env.NotifyWorkers = v
+		} else {
+			s.logger.Warn("Failed to parse NOTIFY_WORKERS environment variable, using default", map[string]interface{}{
+				"value":   notifyWorkersStr,
+				"error":   err.Error(),
+				"default": s.env.NotifyWorkers,
+			})
+		}
+	}
+	if notifyQueueSizeExists {
+		if v, err := strconv.Atoi(notifyQueueSizeStr); err == nil {
+			s.env.NotifyQueueSize = v
+		} else {
+			s.logger.Warn("Failed to parse NOTIFY_QUEUE_SIZE environment variable, using default", map[string]interface{}{
+				"value":   notifyQueueSizeStr,
+				"error":   err.Error(),
+				"default": s.env.NotifyQueueSize,
+			})
+		}
+	}
+	if notifyMaxAttemptsExists {
+		if v, err := strconv.Atoi(notifyMaxAttemptsStr); err == nil {
+			s.env.NotifyMaxAttempts = v
+		} else {
+			s.logger.Warn("Failed to parse NOTIFY_MAX_ATTEMPTS environment variable, using default", map[string]interface{}{
+				"value":   notifyMaxAttemptsStr,
+				"error":   err.Error(),
+				"default": s.env.NotifyMaxAttempts,
+			})
+		}
+	}
+	if notifyRetryBackoffExists {
+		if v, err := strconv.Atoi(notifyRetryBackoffStr); err == nil {
+			s.env.NotifyRetryBackoff = v
+		} else {
+			s.logger.Warn("Failed to parse NOTIFY_RETRY_BACKOFF environment variable, using default", map[string]interface{}{
+				"value":   notifyRetryBackoffStr,
+				"error":   err.Error(),
+				"default": s.env.NotifyRetryBackoff,
+			})
+		}
+	}
 
 	s.logger.Info("Configuration loaded", map[string]interface{}{
-		"name":             s.env.Name,
-		"port":             s.env.Port,
-		"interval":         s.env.Interval,
-		"cpu_threshold":    s.env.CPUThreshold,
-		"memory_threshold": s.env.MemoryThreshold,
-		"disk_threshold":   s.env.DiskThreshold,
-		"webhook_url":      s.env.WebhookUrl,
-		"webhook_username": s.env.WebhookUsername,
-		"background":       s.env.Background,
-		"cooldown":         s.env.Cooldown,
+		"name":                   s.env.Name,
+		"port":                   s.env.Port,
+		"interval":               s.env.Interval,
+		"collector_timeout":      s.env.CollectorTimeout,
+		"cpu_threshold":          s.env.CPUThreshold,
+		"memory_threshold":       s.env.MemoryThreshold,
+		"disk_threshold":         s.env.DiskThreshold,
+		"io_util_threshold":      s.env.IOUtilThreshold,
+		"webhook_url":            s.env.WebhookUrl,
+		"webhook_username":       s.env.WebhookUsername,
+		"slack_enabled":          s.env.SlackWebhookUrl != "",
+		"background":             s.env.Background,
+		"cooldown":               s.env.Cooldown,
+		"process_limit":          s.env.ProcessLimit,
+		"alert_processes":        s.env.AlertProcesses,
+		"disabled_collectors":    s.env.DisabledCollectors,
+		"history_retention":      s.env.HistoryRetention,
+		"history_memory_mb":      s.env.HistoryMemoryMB,
+		"data_dir":               s.env.DataDir,
+		"retention_raw":          s.env.RetentionRaw,
+		"retention_1m":           s.env.Retention1m,
+		"retention_1h":           s.env.Retention1h,
+		"alerting_file":          s.env.AlertingFile,
+		"alert_log_retention":    s.env.AlertLogRetention,
+		"api_token":              s.env.APIToken != "",
+		"forecast_window":        s.env.ForecastWindow,
+		"forecast_horizon":       s.env.ForecastHorizon,
+		"anomaly_metrics":        s.env.AnomalyMetrics,
+		"anomaly_half_life":      s.env.AnomalyHalfLife,
+		"anomaly_seasonal":       s.env.AnomalySeasonal,
+		"anomaly_hour_half_life": s.env.AnomalyHourHalfLife,
+		"anomaly_threshold":      s.env.AnomalyThreshold,
+		"anomaly_for":            s.env.AnomalyFor,
+		"alert_webhook_host":     urlHost(s.env.AlertWebhookUrl),
+		"alert_webhook_signed":   s.env.AlertWebhookSecret != "",
+		"alert_webhook_headers":  len(s.env.AlertWebhookHeaders),
+		"alert_webhook_template": s.env.AlertWebhookTemplate,
+		"smtp_host":              s.env.SMTPHost,
+		"smtp_port":              s.env.SMTPPort,
+		"smtp_username":          s.env.SMTPUsername,
+		"smtp_auth":              s.env.SMTPAuth,
+		"smtp_tls":               s.env.SMTPTLS,
+		"smtp_from":              s.env.SMTPFrom,
+		"smtp_to":                s.env.SMTPTo,
+		"smtp_text_template":     s.env.SMTPTextTemplate,
+		"smtp_html_template":     s.env.SMTPHTMLTemplate,
+		"smtp_batch_window":      s.env.SMTPBatchWindow,
+		"pagerduty_enabled":      s.env.PagerDutyRoutingKey != "",
+		"opsgenie_enabled":       s.env.OpsgenieApiKey != "",
+		"opsgenie_api_url":       s.env.OpsgenieApiUrl,
+		"telegram_enabled":       s.env.TelegramBotToken != "" && s.env.TelegramChatID != "",
+		"telegram_api_url":       s.env.TelegramApiUrl,
+		"ntfy_url":               s.env.NtfyUrl,
+		"ntfy_topic":             s.env.NtfyTopic,
+		"ntfy_tags":              s.env.NtfyTags,
+		"gotify_url":             s.env.GotifyUrl,
+		"exec_command":           s.env.ExecCommand,
+		"exec_args":              s.env.ExecArgs,
+		"exec_timeout":           s.env.ExecTimeout,
+		"exec_concurrency":       s.env.ExecConcurrency,
+		"notify_workers":         s.env.NotifyWorkers,
+		"notify_queue_size":      s.env.NotifyQueueSize,
+		"notify_max_attempts":    s.env.NotifyMaxAttempts,
+		"notify_retry_backoff":   s.env.NotifyRetryBackoff,
 	})
 
 	return nil
@@ -244,6 +857,13 @@ func (s *Service) Validate() error {
 		return ErrInvalidInterval
 	}
 
+	if s.env.CollectorTimeout <= 0 {
+		s.logger.Error("COLLECTOR_TIMEOUT must be positive", map[string]interface{}{
+			"collector_timeout": s.env.CollectorTimeout,
+		})
+		return ErrInvalidCollectorTimeout
+	}
+
 	if s.env.CPUThreshold < 1 || s.env.CPUThreshold > 100 {
 		s.logger.Error("CPU_THRESHOLD must be between 1 and 100", map[string]interface{}{
 			"cpu_threshold": s.env.CPUThreshold,
@@ -265,6 +885,153 @@ func (s *Service) Validate() error {
 		return ErrInvalidDiskThreshold
 	}
 
+	if s.env.IOUtilThreshold < 1 || s.env.IOUtilThreshold > 100 {
+		s.logger.Error("IO_UTIL_THRESHOLD must be between 1 and 100", map[string]interface{}{
+			"io_util_threshold": s.env.IOUtilThreshold,
+		})
+		return ErrInvalidIOUtilThreshold
+	}
+
+	if strings.Contains(s.env.SlackWebhookUrl, "://") && !strings.HasPrefix(s.env.SlackWebhookUrl, "https://hooks.slack.com/services/") {
+		s.logger.Error("SLACK_WEBHOOK_URL must be a Slack incoming webhook URL or its path", map[string]interface{}{
+			"slack_webhook_host": urlHost(s.env.SlackWebhookUrl),
+		})
+		return ErrInvalidSlackWebhookUrl
+	}
+
+	if s.env.AlertWebhookUrl != "" && !isHTTPURL(s.env.AlertWebhookUrl) {
+		s.logger.Error("ALERT_WEBHOOK_URL must be an absolute http or https URL", map[string]interface{}{
+			"alert_webhook_host": urlHost(s.env.AlertWebhookUrl),
+		})
+		return ErrInvalidAlertWebhookUrl
+	}
+
+	if s.env.SMTPHost != "" {
+		if err := s.validateSMTP(); err != nil {
+			return err
+		}
+	}
+
+	if s.env.OpsgenieApiKey != "" && !isHTTPURL(s.env.OpsgenieApiUrl) {
+		s.logger.Error("OPSGENIE_API_URL must be an absolute http or https URL", map[string]interface{}{
+			"opsgenie_api_url": s.env.OpsgenieApiUrl,
+		})
+		return ErrInvalidOpsgenieApiUrl
+	}
+
+	if s.env.TelegramBotToken != "" && !isHTTPURL(s.env.TelegramApiUrl) {
+		s.logger.Error("TELEGRAM_API_URL must be an absolute http or https URL", map[string]interface{}{
+			"telegram_api_url": s.env.TelegramApiUrl,
+		})
+		return ErrInvalidTelegramApiUrl
+	}
+
+	if s.env.NtfyTopic != "" && !isHTTPURL(s.env.NtfyUrl) {
+		s.logger.Error("NTFY_URL must be an absolute http or https URL", map[string]interface{}{
+			"ntfy_url": s.env.NtfyUrl,
+		})
+		return ErrInvalidNtfyUrl
+	}
+
+	if s.env.GotifyUrl != "" && !isHTTPURL(s.env.GotifyUrl) {
+		s.logger.Error("GOTIFY_URL must be an absolute http or https URL", map[string]interface{}{
+			"gotify_url": s.env.GotifyUrl,
+		})
+		return ErrInvalidGotifyUrl
+	}
+
+	if s.env.ExecCommand != "" {
+		if s.env.ExecTimeout <= 0 {
+			s.logger.Error("EXEC_TIMEOUT must be positive", map[string]interface{}{
+				"exec_timeout": s.env.ExecTimeout,
+			})
+			return ErrInvalidExecTimeout
+		}
+		if s.env.ExecConcurrency <= 0 {
+			s.logger.Error("EXEC_CONCURRENCY must be positive", map[string]interface{}{
+				"exec_concurrency": s.env.ExecConcurrency,
+			})
+			return ErrInvalidExecConcurrency
+		}
+	}
+
+	if s.env.NotifyWorkers <= 0 {
+		s.logger.Error("NOTIFY_WORKERS must be positive", map[string]interface{}{
+			"notify_workers": s.env.NotifyWorkers,
+		})
+		return ErrInvalidNotifyWorkers
+	}
+
+	if s.env.NotifyQueueSize <= 0 {
+		s.logger.Error("NOTIFY_QUEUE_SIZE must be positive", map[string]interface{}{
+			"notify_queue_size": s.env.NotifyQueueSize,
+		})
+		return ErrInvalidNotifyQueueSize
+	}
+
+	if s.env.NotifyMaxAttempts <= 0 {
+		s.logger.Error("NOTIFY_MAX_ATTEMPTS must be positive", map[string]interface{}{
+			"notify_max_attempts": s.env.NotifyMaxAttempts,
+		})
+		return ErrInvalidNotifyMaxAttempts
+	}
+
+	if s.env.NotifyRetryBackoff <= 0 {
+		s.logger.Error("NOTIFY_RETRY_BACKOFF must be positive", map[string]interface{}{
+			"notify_retry_backoff": s.env.NotifyRetryBackoff,
+		})
+		return ErrInvalidNotifyRetryBackoff
+	}
+
+	if s.env.AlertLogRetention <= 0 {
+		s.logger.Error("ALERT_LOG_RETENTION must be positive", map[string]interface{}{
+			"alert_log_retention": s.env.AlertLogRetention,
+		})
+		return ErrInvalidAlertLogRetention
+	}
+
+	if s.env.ForecastWindow <= 0 {
+		s.logger.Error("FORECAST_WINDOW must be positive", map[string]interface{}{
+			"forecast_window": s.env.ForecastWindow,
+		})
+		return ErrInvalidForecastWindow
+	}
+
+	if s.env.ForecastHorizon < 0 {
+		s.logger.Error("FORECAST_HORIZON must not be negative", map[string]interface{}{
+			"forecast_horizon": s.env.ForecastHorizon,
+		})
+		return ErrInvalidForecastHorizon
+	}
+
+	if s.env.AnomalyHalfLife <= 0 {
+		s.logger.Error("ANOMALY_HALF_LIFE must be positive", map[string]interface{}{
+			"anomaly_half_life": s.env.AnomalyHalfLife,
+		})
+		return ErrInvalidAnomalyHalfLife
+	}
+
+	if s.env.AnomalyHourHalfLife <= 0 {
+		s.logger.Error("ANOMALY_HOUR_HALF_LIFE must be positive", map[string]interface{}{
+			"anomaly_hour_half_life": s.env.AnomalyHourHalfLife,
+		})
+		return ErrInvalidAnomalyHourHalfLife
+	}
+
+	if s.env.AnomalyThreshold <= 0 {
+		s.logger.Error("ANOMALY_THRESHOLD must be positive", map[string]interface{}{
+			"anomaly_threshold": s.env.AnomalyThreshold,
+		})
+		return ErrInvalidAnomalyThreshold
+	}
+
+	if s.env.AnomalyFor < 0 {
+		s.logger.Error("ANOMALY_FOR must not be negative", map[string]interface{}{
+			"anomaly_for": s.env.AnomalyFor,
+		})
+		return ErrInvalidAnomalyFor
+	}
+
 	if s.env.Cooldown <= 0 {
 		s.logger.Error("COOLDOWN must be positive", map[string]interface{}{
 			"cooldown": s.env.Cooldown,
@@ -272,5 +1039,150 @@ func (s *Service) Validate() error {
 		return ErrInvalidCooldown
 	}
 
+	if s.env.ProcessLimit < 0 {
+		s.logger.Error("PROCESS_LIMIT must not be negative", map[string]interface{}{
+			"process_limit": s.env.ProcessLimit,
+		})
+		return ErrInvalidProcessLimit
+	}
+
+	if s.env.AlertProcesses < 0 {
+		s.logger.Error("ALERT_PROCESSES must not be negative", map[string]interface{}{
+			"alert_processes": s.env.AlertProcesses,
+		})
+		return ErrInvalidAlertProcesses
+	}
+
+	if s.env.HistoryRetention < 0 {
+		s.logger.Error("HISTORY_RETENTION must not be negative", map[string]interface{}{
+			"history_retention": s.env.HistoryRetention,
+		})
+		return ErrInvalidHistoryRetention
+	}
+
+	if s.env.HistoryMemoryMB <= 0 {
+		s.logger.Error("HISTORY_MEMORY_MB must be positive", map[string]interface{}{
+			"history_memory_mb": s.env.HistoryMemoryMB,
+		})
+		return ErrInvalidHistoryMemoryMB
+	}
+
+	if s.env.RetentionRaw <= 0 {
+		s.logger.Error("RETENTION_RAW must be positive", map[string]interface{}{
+			"retention_raw": s.env.RetentionRaw,
+		})
+		return ErrInvalidRetentionRaw
+	}
+
+	if s.env.Retention1m <= 0 {
+		s.logger.Error("RETENTION_1M must be positive", map[string]interface{}{
+			"retention_1m": s.env.Retention1m,
+		})
+		return ErrInvalidRetention1m
+	}
+
+	if s.env.Retention1h <= 0 {
+		s.logger.Error("RETENTION_1H must be positive", map[string]interface{}{
+			"retention_1h": s.env.Retention1h,
+		})
+		return ErrInvalidRetention1h
+	}
+
 	return nil
 }
+
+// validateSMTP checks the email settings once an SMTP host is configured
+func (s *Service) validateSMTP() error {
+	if s.env.SMTPPort < 1 || s.env.SMTPPort > 65535 {
+		s.logger.Error("SMTP_PORT must be between 1 and 65535", map[string]interface{}{
+			"smtp_port": s.env.SMTPPort,
+		})
+		return ErrInvalidSMTPPort
+	}
+
+	if s.env.SMTPAuth != "plain" && s.env.SMTPAuth != "login" {
+		s.logger.Error("SMTP_AUTH must be plain or login", map[string]interface{}{
+			"smtp_auth": s.env.SMTPAuth,
+		})
+		return ErrInvalidSMTPAuth
+	}
+
+	if s.env.SMTPTLS != "starttls" && s.env.SMTPTLS != "tls" && s.env.SMTPTLS != "none" {
+		s.logger.Error("SMTP_TLS must be starttls, tls or none", map[string]interface{}{
+			"smtp_tls": s.env.SMTPTLS,
+		})
+		return ErrInvalidSMTPTLS
+	}
+
+	if _, err := mail.ParseAddress(s.env.SMTPFrom); err != nil {
+		s.logger.Error("SMTP_FROM must be a valid address", map[string]interface{}{
+			"smtp_from": s.env.SMTPFrom,
+		})
+		return ErrInvalidSMTPAddresses
+	}
+	if len(s.env.SMTPTo) == 0 {
+		s.logger.Error("SMTP_TO must list at least one recipient", map[string]interface{}{})
+		return ErrInvalidSMTPAddresses
+	}
+	for _, to := range s.env.SMTPTo {
+		if _, err := mail.ParseAddress(to); err != nil {
+			s.logger.Error("SMTP_TO contains an invalid address", map[string]interface{}{
+				"address": to,
+			})
+			return ErrInvalidSMTPAddresses
+		}
+	}
+
+	if s.env.SMTPBatchWindow < 0 {
+		s.logger.Error("SMTP_BATCH_WINDOW must not be negative", map[string]interface{}{
+			"smtp_batch_window": s.env.SMTPBatchWindow,
+		})
+		return ErrInvalidSMTPBatchWindow
+	}
+
+	return nil
+}
+
+// isHTTPURL reports whether value is an absolute http or https URL
+func isHTTPURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// urlHost returns the host of a URL for logging, leaving out the path and query
+// that may carry credentials
+func urlHost(value string) string {
+	u, err := url.Parse(value)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+// parseHeaders splits a comma separated list of "Name: value" headers
+// Entries without a name are returned separately
+func parseHeaders(value string) (map[string]string, []string) {
+	headers := make(map[string]string)
+	var invalid []string
+	for _, item := range splitList(value) {
+		name, val, found := strings.Cut(item, ":")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			invalid = append(invalid, item)
+			continue
+		}
+		headers[name] = strings.TrimSpace(val)
+	}
+	return headers, invalid
+}
+
+// splitList parses a comma separated list, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
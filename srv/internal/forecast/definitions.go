@@ -0,0 +1,48 @@
+package forecast
+
+import "time"
+
+// Metrics carrying the forecast time to exhaustion in seconds, labelled like their usage metric
+const (
+	MetricDiskTimeToFull   = "disk.time_to_full_seconds"
+	MetricInodesTimeToFull = "disk.inodes_time_to_full_seconds"
+	MetricSwapTimeToFull   = "memory.swap_time_to_full_seconds"
+)
+
+// Never is the time to full, in seconds, reported for series that are not
+// growing or would take longer than ten years to fill
+const Never = 10 * 365 * 24 * 60 * 60
+
+// maxPoints bounds the points kept per series; samples closer together than
+// window/maxPoints are not recorded
+const maxPoints = 360
+
+// minSpanDivisor sets how much history a series needs before it is forecast:
+// its points have to span at least window/minSpanDivisor
+const minSpanDivisor = 10
+
+// Target is a usage metric forecast to reach its capacity
+type Target struct {
+	Metric   string  // Usage metric (e.g. "disk.used_percent")
+	Output   string  // Metric carrying the forecast time to full
+	Capacity float64 // Value at which the resource is exhausted
+}
+
+// Targets lists the forecast usage metrics
+var Targets = []Target{
+	{Metric: "disk.used_percent", Output: MetricDiskTimeToFull, Capacity: 100},
+	{Metric: "disk.inodes_used_percent", Output: MetricInodesTimeToFull, Capacity: 100},
+	{Metric: "memory.swap_used_percent", Output: MetricSwapTimeToFull, Capacity: 100},
+}
+
+// Forecast is the projected exhaustion of one usage series
+type Forecast struct {
+	Metric     string            `json:"metric"`               // Usage metric
+	Labels     map[string]string `json:"labels"`               // Labels of the series (e.g. mountpoint)
+	Value      float64           `json:"value"`                // Latest value
+	Slope      float64           `json:"slope"`                // Fitted growth per hour
+	TimeToFull *float64          `json:"timeToFull,omitempty"` // Seconds until the capacity is reached, absent when not filling
+	FullAt     time.Time         `json:"fullAt,omitzero"`      // When the capacity is reached
+	R2         float64           `json:"r2"`                   // Coefficient of determination of the fit (1 is a perfect line)
+	Points     int               `json:"points"`               // Points the fit is based on
+}
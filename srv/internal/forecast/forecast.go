@@ -0,0 +1,276 @@
+package forecast
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/LissaiDev/Delphos/internal/config"
+	"github.com/LissaiDev/Delphos/internal/history"
+	"github.com/LissaiDev/Delphos/internal/monitor"
+	"github.com/LissaiDev/Delphos/pkg/logger"
+)
+
+// point is a recorded usage value
+type point struct {
+	at    time.Time
+	value float64
+}
+
+// series holds the recent points of one usage series, oldest first
+type series struct {
+	target *Target
+	labels map[string]string
+	points []point
+	latest float64
+}
+
+// Forecaster projects when usage series reach their capacity
+// Every series of the target metrics is fitted with a least squares line
+// over the window, and the time to full is where the line crosses the capacity
+type Forecaster struct {
+	logger    logger.BasicLogger
+	window    time.Duration
+	step      time.Duration // Minimum spacing of recorded points
+	series    map[string]*series
+	forecasts []Forecast // Results of the latest observation
+	mu        sync.Mutex
+}
+
+var (
+	forecasterInstance *Forecaster
+	once               sync.Once
+)
+
+// New creates a forecaster fitting the given window of history
+func New(log logger.BasicLogger, window time.Duration) *Forecaster {
+	return &Forecaster{
+		logger: log,
+		window: window,
+		step:   window / maxPoints,
+		series: make(map[string]*series),
+	}
+}
+
+// GetInstance returns the shared forecaster configured from config.Env
+// It starts from the recorded history, so forecasts are available right after a restart
+func GetInstance() *Forecaster {
+	once.Do(func() {
+		forecasterInstance = New(logger.GetInstance(), time.Duration(config.Env.ForecastWindow)*time.Second)
+		if store := history.GetInstance(); store.Enabled() {
+			forecasterInstance.Seed(store, time.Now())
+		}
+	})
+	return forecasterInstance
+}
+
+// Window returns the span of history fitted
+func (f *Forecaster) Window() time.Duration {
+	return f.window
+}
+
+// Seed loads the points of the window before now from the history store
+func (f *Forecaster) Seed(store *history.Store, now time.Time) {
+	step := max(f.step.Truncate(time.Second), time.Second)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	loaded := 0
+	for i := range Targets {
+		target := &Targets[i]
+		result, err := store.Query(history.Query{
+			Metric:    target.Metric,
+			From:      now.Add(-f.window),
+			To:        now,
+			Step:      step,
+			Aggregate: history.AggregateAvg,
+		})
+		if err != nil {
+			f.logger.Warn("Failed to load usage history for forecasts", map[string]interface{}{
+				"metric": target.Metric,
+				"error":  err.Error(),
+			})
+			continue
+		}
+
+		for _, rs := range result.Series {
+			s := &series{target: target, labels: rs.Labels}
+			for i, value := range rs.Values {
+				if value == nil {
+					continue
+				}
+				s.points = append(s.points, point{at: time.Unix(result.Timestamps[i], 0), value: *value})
+				s.latest = *value
+			}
+			if len(s.points) > 0 {
+				f.series[monitor.SeriesKey(target.Metric, rs.Labels)] = s
+				loaded++
+			}
+		}
+	}
+
+	f.logger.Info("Forecasts seeded from history", map[string]interface{}{
+		"series": loaded,
+		"window": f.window.String(),
+	})
+}
+
+// Observe records a batch of samples taken at the given time and returns the
+// forecast time to full of every series with enough history, as samples of the
+// targets' output metrics. Series missing from a batch that has samples of their
+// metric are forgotten (e.g. an unmounted filesystem).
+func (f *Forecaster) Observe(at time.Time, samples []monitor.Sample) []monitor.Sample {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	seen := make(map[string]bool)
+	reported := make(map[string]bool)
+	for _, sample := range samples {
+		target := targetFor(sample.Name)
+		if target == nil {
+			continue
+		}
+		reported[target.Metric] = true
+
+		key := sample.Key()
+		seen[key] = true
+		s := f.series[key]
+		if s == nil {
+			s = &series{target: target, labels: sample.Labels}
+			f.series[key] = s
+		}
+		s.add(at, sample.Value, f.step, f.window)
+	}
+
+	keys := make([]string, 0, len(f.series))
+	for key, s := range f.series {
+		if reported[s.target.Metric] && !seen[key] {
+			delete(f.series, key)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var derived []monitor.Sample
+	forecasts := make([]Forecast, 0, len(keys))
+	for _, key := range keys {
+		s := f.series[key]
+		forecast, ok := s.fit(at, f.window)
+		if !ok {
+			continue
+		}
+		forecasts = append(forecasts, forecast)
+
+		timeToFull := float64(Never)
+		if forecast.TimeToFull != nil {
+			timeToFull = *forecast.TimeToFull
+		}
+		derived = append(derived, monitor.Sample{
+			Name:   s.target.Output,
+			Type:   monitor.MetricGauge,
+			Labels: s.labels,
+			Value:  timeToFull,
+		})
+	}
+	f.forecasts = forecasts
+
+	return derived
+}
+
+// Forecasts returns the forecasts of the latest observation, ordered by metric and labels
+func (f *Forecaster) Forecasts() []Forecast {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	forecasts := make([]Forecast, len(f.forecasts))
+	copy(forecasts, f.forecasts)
+	return forecasts
+}
+
+// add records a value, dropping points that left the window
+func (s *series) add(at time.Time, value float64, step, window time.Duration) {
+	s.latest = value
+	if n := len(s.points); n == 0 || at.Sub(s.points[n-1].at) >= step {
+		s.points = append(s.points, point{at: at, value: value})
+	}
+
+	cutoff := at.Add(-window)
+	drop := 0
+	for drop < len(s.points) && s.points[drop].at.Before(cutoff) {
+		drop++
+	}
+	if drop > 0 {
+		s.points = append(s.points[:0], s.points[drop:]...)
+	}
+}
+
+// fit projects the series from a least squares line over its points
+// ok is false until the points span enough of the window to fit
+func (s *series) fit(now time.Time, window time.Duration) (forecast Forecast, ok bool) {
+	n := len(s.points)
+	if n < 3 || s.points[n-1].at.Sub(s.points[0].at) < window/minSpanDivisor {
+		return forecast, false
+	}
+
+	// Times are taken relative to now, so the intercept is the fitted value now
+	var meanX, meanY float64
+	for _, p := range s.points {
+		meanX += p.at.Sub(now).Seconds()
+		meanY += p.value
+	}
+	meanX /= float64(n)
+	meanY /= float64(n)
+
+	var sxx, sxy, syy float64
+	for _, p := range s.points {
+		dx := p.at.Sub(now).Seconds() - meanX
+		dy := p.value - meanY
+		sxx += dx * dx
+		sxy += dx * dy
+		syy += dy * dy
+	}
+	if sxx == 0 {
+		return forecast, false
+	}
+	slope := sxy / sxx // Per second
+	intercept := meanY - slope*meanX
+
+	forecast = Forecast{
+		Metric: s.target.Metric,
+		Labels: s.labels,
+		Value:  s.latest,
+		Slope:  slope * 3600,
+		R2:     1,
+		Points: n,
+	}
+	if syy > 0 {
+		forecast.R2 = sxy * sxy / (sxx * syy)
+	}
+
+	var timeToFull float64
+	switch {
+	case s.latest >= s.target.Capacity || intercept >= s.target.Capacity:
+		timeToFull = 0
+	case slope > 0:
+		timeToFull = min((s.target.Capacity-intercept)/slope, Never)
+	default:
+		return forecast, true
+	}
+	if timeToFull < Never {
+		forecast.TimeToFull = &timeToFull
+		forecast.FullAt = now.Add(time.Duration(timeToFull * float64(time.Second)))
+	}
+	return forecast, true
+}
+
+// targetFor returns the target forecasting a metric, nil when the metric is not forecast
+func targetFor(metric string) *Target {
+	for i := range Targets {
+		if Targets[i].Metric == metric {
+			return &Targets[i]
+		}
+	}
+	return nil
+}
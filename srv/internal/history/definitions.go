@@ -0,0 +1,55 @@
+package history
+
+import (
+	"errors"
+	"time"
+)
+
+// Point is a single stored sample of a series
+type Point struct {
+	Timestamp int64   // Unix time in milliseconds
+	Value     float64 // Sampled value
+}
+
+// Series is one label set of a metric in a query result
+// Values are aligned with QueryResult.Timestamps; steps without samples are null
+type Series struct {
+	Labels  map[string]string `json:"labels"`            // Labels identifying the series
+	Values  []*float64        `json:"values"`            // Samples in each step reduced with the query aggregate
+	Anomaly []*float64        `json:"anomaly,omitempty"` // Highest anomaly score in each step, when requested
+}
+
+// QueryResult holds the aligned series returned for a range query
+type QueryResult struct {
+	Metric     string    `json:"metric"`     // Queried metric name
+	Resolution string    `json:"resolution"` // Resolution the values were read from ("raw", "1m" or "1h")
+	Aggregate  string    `json:"aggregate"`  // Function used to reduce each step
+	From       int64     `json:"from"`       // Start of the first step in Unix seconds
+	To         int64     `json:"to"`         // Start of the last step in Unix seconds
+	Step       int64     `json:"step"`       // Step width in seconds
+	Timestamps []int64   `json:"timestamps"` // Start of each step in Unix seconds
+	Series     []*Series `json:"series"`     // One entry per matching label set
+}
+
+// Query describes a range query against the store
+type Query struct {
+	Metric    string            // Metric name (e.g. "cpu.usage")
+	Matchers  map[string]string // Optional label values the series must have
+	From      time.Time         // Start of the range (inclusive)
+	To        time.Time         // End of the range (inclusive)
+	Step      time.Duration     // Width of each step, a whole number of seconds
+	Aggregate string            // "avg" (default), "min", "max" or "last"
+}
+
+// MaxQueryPoints is the largest number of steps a single query may return
+const MaxQueryPoints = 11000
+
+// History errors
+var (
+	ErrInvalidRange  = errors.New("invalid time range")
+	ErrInvalidStep   = errors.New("step must be a whole number of seconds")
+	ErrTooManyPoints = errors.New("query exceeds the maximum number of points")
+	ErrMissingMetric = errors.New("metric is required")
+
+	ErrInvalidAggregate = errors.New("aggregate must be one of: avg, min, max, last")
+)
@@ -0,0 +1,377 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/LissaiDev/Delphos/internal/monitor"
+	"github.com/LissaiDev/Delphos/pkg/logger"
+)
+
+// Resolution names, also used as subdirectories of the data directory
+const (
+	ResolutionRaw    = "raw"
+	ResolutionMinute = "1m"
+	ResolutionHour   = "1h"
+)
+
+// retentionCheckInterval is how often expired segments are looked for
+const retentionCheckInterval = time.Minute
+
+// resolution is one level of stored data
+type resolution struct {
+	name      string
+	kind      byte          // Record kind written at this level
+	width     time.Duration // Bucket width, 0 for raw samples
+	window    time.Duration // Time span covered by one segment file
+	retention time.Duration // How long segments are kept
+	dir       string
+	active    *segment // Segment currently appended to, nil until needed
+	last      int64    // Timestamp of the newest record found when opening, 0 when empty
+}
+
+// covers reports whether data from t is still retained at this resolution
+func (r *resolution) covers(t, now time.Time) bool {
+	return !t.Before(now.Add(-r.retention))
+}
+
+// DiskStore persists samples in append-only segment files and maintains
+// 1 minute and 1 hour rollups (min/max/avg/last) alongside the raw data
+type DiskStore struct {
+	logger        logger.BasicLogger
+	raw           *resolution
+	minute        *resolution
+	hour          *resolution
+	minuteRollup  *rollup
+	hourRollup    *rollup
+	lastRetention time.Time
+	mu            sync.Mutex
+}
+
+// OpenDisk opens the segment store under dir, creating it if needed
+// Truncated trailing records left by a crash are dropped, and samples not yet
+// rolled up are replayed so the pending 1m and 1h buckets survive a restart
+func OpenDisk(log logger.BasicLogger, dir string, rawRetention, minuteRetention, hourRetention time.Duration) (*DiskStore, error) {
+	d := &DiskStore{
+		logger:       log,
+		raw:          &resolution{name: ResolutionRaw, kind: recordRaw, window: time.Hour, retention: rawRetention},
+		minute:       &resolution{name: ResolutionMinute, kind: recordRollup, width: time.Minute, window: 24 * time.Hour, retention: minuteRetention},
+		hour:         &resolution{name: ResolutionHour, kind: recordRollup, width: time.Hour, window: 30 * 24 * time.Hour, retention: hourRetention},
+		minuteRollup: newRollup(time.Minute.Milliseconds()),
+		hourRollup:   newRollup(time.Hour.Milliseconds()),
+	}
+
+	for _, res := range d.resolutions() {
+		res.dir = filepath.Join(dir, res.name)
+		if err := os.MkdirAll(res.dir, 0o755); err != nil {
+			d.Close()
+			return nil, err
+		}
+		if err := d.recover(res); err != nil {
+			d.Close()
+			return nil, err
+		}
+	}
+
+	// Rebuild the buckets that were still open when the store was last closed
+	if err := d.replay(d.raw, d.minuteRollup, d.watermark(d.minute)); err != nil {
+		d.Close()
+		return nil, err
+	}
+	if err := d.replay(d.minute, d.hourRollup, d.watermark(d.hour)); err != nil {
+		d.Close()
+		return nil, err
+	}
+
+	d.enforceRetention(time.Now())
+
+	log.Info("Metric storage opened", map[string]interface{}{
+		"data_dir":         dir,
+		"raw_retention":    rawRetention.String(),
+		"minute_retention": minuteRetention.String(),
+		"hour_retention":   hourRetention.String(),
+	})
+
+	return d, nil
+}
+
+// Append persists a batch of samples taken at the given time and writes any
+// rollup buckets completed by it
+func (d *DiskStore) Append(at time.Time, samples []monitor.Sample) error {
+	ts := at.UnixMilli()
+	raw := batch{Timestamp: ts, Entries: make([]entry, 0, len(samples))}
+	for _, sample := range samples {
+		raw.Entries = append(raw.Entries, entry{Name: sample.Name, Labels: sample.Labels, Agg: newAggregate(sample.Value)})
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := d.write(d.raw, raw); err != nil {
+		return err
+	}
+
+	for _, e := range raw.Entries {
+		d.minuteRollup.add(ts, e)
+	}
+	for _, b := range d.minuteRollup.flush(ts) {
+		if err := d.write(d.minute, b); err != nil {
+			return err
+		}
+		for _, e := range b.Entries {
+			d.hourRollup.add(b.Timestamp, e)
+		}
+	}
+	for _, b := range d.hourRollup.flush(ts) {
+		if err := d.write(d.hour, b); err != nil {
+			return err
+		}
+	}
+
+	if at.Sub(d.lastRetention) >= retentionCheckInterval {
+		d.enforceRetention(at)
+	}
+	return nil
+}
+
+// Read returns the stored points of the matching series between from and to
+// at the given resolution, keyed by series
+// Rollup buckets that are still open are included so the newest step is not missing
+func (d *DiskStore) Read(res *resolution, metric string, matchers map[string]string, from, to time.Time) (map[string]*storedSeries, error) {
+	fromMs, toMs := from.UnixMilli(), to.UnixMilli()
+	result := make(map[string]*storedSeries)
+	visit := func(b batch) {
+		if b.Timestamp < fromMs || b.Timestamp > toMs {
+			return
+		}
+		for _, e := range b.Entries {
+			if e.Name != metric || !matches(e.Labels, matchers) {
+				continue
+			}
+			key := monitor.SeriesKey(e.Name, e.Labels)
+			sr, exists := result[key]
+			if !exists {
+				sr = &storedSeries{labels: e.Labels}
+				result[key] = sr
+			}
+			sr.points = append(sr.points, storedPoint{Timestamp: b.Timestamp, Agg: e.Agg})
+		}
+	}
+
+	d.mu.Lock()
+	starts, err := listSegments(res.dir)
+	switch res {
+	case d.minute:
+		d.minuteRollup.visitPending(visit)
+	case d.hour:
+		d.minuteRollup.visitPending(visit)
+		d.hourRollup.visitPending(visit)
+	}
+	d.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	window := res.window.Milliseconds()
+	for _, start := range starts {
+		if start+window <= fromMs || start > toMs {
+			continue
+		}
+		if err := readSegment(segmentPath(res.dir, start), visit); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, sr := range result {
+		sr.sort()
+	}
+	return result, nil
+}
+
+// Close syncs and closes the active segments
+func (d *DiskStore) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var firstErr error
+	for _, res := range d.resolutions() {
+		if res.active == nil {
+			continue
+		}
+		if err := res.active.close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		res.active = nil
+	}
+	return firstErr
+}
+
+// resolutions returns the stored levels from finest to coarsest
+func (d *DiskStore) resolutions() []*resolution {
+	return []*resolution{d.raw, d.minute, d.hour}
+}
+
+// recover opens the newest segment of a resolution, truncating a damaged tail,
+// and finds the timestamp of the newest intact record
+func (d *DiskStore) recover(res *resolution) error {
+	starts, err := listSegments(res.dir)
+	if err != nil || len(starts) == 0 {
+		return err
+	}
+
+	start := starts[len(starts)-1]
+	seg, truncated, err := openSegment(res.dir, start, res.window.Milliseconds())
+	if err != nil {
+		return err
+	}
+	if truncated > 0 {
+		d.logger.Warn("Truncated damaged tail of metric segment", map[string]interface{}{
+			"segment":         seg.path,
+			"truncated_bytes": truncated,
+		})
+	}
+	res.active = seg
+	res.last = seg.last
+
+	// The newest segment may have lost its only records; look further back
+	for i := len(starts) - 2; i >= 0 && res.last == 0; i-- {
+		err := readSegment(segmentPath(res.dir, starts[i]), func(b batch) {
+			if b.Timestamp > res.last {
+				res.last = b.Timestamp
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// watermark returns the end of the newest bucket written at a rollup resolution
+func (d *DiskStore) watermark(res *resolution) int64 {
+	if res.last == 0 {
+		return 0
+	}
+	return res.last + res.width.Milliseconds()
+}
+
+// replay feeds the records of res from since onwards into acc
+func (d *DiskStore) replay(res *resolution, acc *rollup, since int64) error {
+	starts, err := listSegments(res.dir)
+	if err != nil {
+		return err
+	}
+
+	window := res.window.Milliseconds()
+	replayed := 0
+	for _, start := range starts {
+		if start+window <= since {
+			continue
+		}
+		err := readSegment(segmentPath(res.dir, start), func(b batch) {
+			if b.Timestamp < since {
+				return
+			}
+			for _, e := range b.Entries {
+				acc.add(b.Timestamp, e)
+			}
+			replayed++
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if replayed > 0 {
+		d.logger.Debug("Replayed metric records into pending rollups", map[string]interface{}{
+			"resolution": res.name,
+			"records":    replayed,
+		})
+	}
+	return nil
+}
+
+// write appends a batch to the segment covering its timestamp; callers hold the lock
+func (d *DiskStore) write(res *resolution, b batch) error {
+	seg := res.active
+	if seg == nil || b.Timestamp < seg.start || b.Timestamp >= seg.end {
+		window := res.window.Milliseconds()
+		start := b.Timestamp - b.Timestamp%window
+
+		if seg != nil {
+			if err := seg.close(); err != nil {
+				d.logger.Warn("Failed to close metric segment", map[string]interface{}{
+					"segment": seg.path,
+					"error":   err.Error(),
+				})
+			}
+			res.active = nil
+		}
+
+		opened, truncated, err := openSegment(res.dir, start, window)
+		if err != nil {
+			d.logger.Error("Failed to open metric segment", map[string]interface{}{
+				"resolution": res.name,
+				"error":      err.Error(),
+			})
+			return err
+		}
+		if truncated > 0 {
+			d.logger.Warn("Truncated damaged tail of metric segment", map[string]interface{}{
+				"segment":         opened.path,
+				"truncated_bytes": truncated,
+			})
+		}
+		res.active = opened
+		seg = opened
+	}
+
+	if err := seg.write(res.kind, b); err != nil {
+		d.logger.Error("Failed to write metric segment", map[string]interface{}{
+			"segment": seg.path,
+			"error":   err.Error(),
+		})
+		return err
+	}
+	return nil
+}
+
+// enforceRetention removes segments whose whole window is past retention; callers hold the lock
+func (d *DiskStore) enforceRetention(now time.Time) {
+	d.lastRetention = now
+
+	for _, res := range d.resolutions() {
+		starts, err := listSegments(res.dir)
+		if err != nil {
+			d.logger.Warn("Failed to list metric segments", map[string]interface{}{
+				"resolution": res.name,
+				"error":      err.Error(),
+			})
+			continue
+		}
+
+		cutoff := now.Add(-res.retention).UnixMilli()
+		window := res.window.Milliseconds()
+		for _, start := range starts {
+			if start+window > cutoff {
+				break
+			}
+			if res.active != nil && res.active.start == start {
+				continue
+			}
+			path := segmentPath(res.dir, start)
+			if err := os.Remove(path); err != nil {
+				d.logger.Warn("Failed to remove expired metric segment", map[string]interface{}{
+					"segment": path,
+					"error":   err.Error(),
+				})
+				continue
+			}
+			d.logger.Debug("Removed expired metric segment", map[string]interface{}{
+				"segment": path,
+			})
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package history
+
+import (
+	"math"
+	"sort"
+
+	"github.com/LissaiDev/Delphos/internal/monitor"
+)
+
+// Aggregation functions accepted by Query.Aggregate
+const (
+	AggregateAvg  = "avg"
+	AggregateMin  = "min"
+	AggregateMax  = "max"
+	AggregateLast = "last"
+)
+
+// aggregate summarizes the samples of a series within a time bucket
+type aggregate struct {
+	Min   float64
+	Max   float64
+	Sum   float64
+	Count uint64
+	Last  float64
+}
+
+// newAggregate summarizes a single sample
+func newAggregate(value float64) aggregate {
+	return aggregate{Min: value, Max: value, Sum: value, Count: 1, Last: value}
+}
+
+// merge folds a later summary into a
+func (a *aggregate) merge(b aggregate) {
+	if a.Count == 0 {
+		*a = b
+		return
+	}
+	a.Min = math.Min(a.Min, b.Min)
+	a.Max = math.Max(a.Max, b.Max)
+	a.Sum += b.Sum
+	a.Count += b.Count
+	a.Last = b.Last
+}
+
+// value returns the summary reduced with the given aggregation function
+func (a aggregate) value(fn string) float64 {
+	switch fn {
+	case AggregateMin:
+		return a.Min
+	case AggregateMax:
+		return a.Max
+	case AggregateLast:
+		return a.Last
+	default:
+		return a.Sum / float64(a.Count)
+	}
+}
+
+// entry is one series' summary within a stored batch
+type entry struct {
+	Name   string
+	Labels map[string]string
+	Agg    aggregate
+}
+
+// batch groups the entries recorded for one timestamp (or bucket start)
+type batch struct {
+	Timestamp int64 // Unix time in milliseconds
+	Entries   []entry
+}
+
+// rollup accumulates entries into fixed width buckets until they are complete
+type rollup struct {
+	width   int64                       // Bucket width in milliseconds
+	buckets map[int64]map[string]*entry // Bucket start -> series key -> summary
+}
+
+// newRollup creates an accumulator for buckets of width milliseconds
+func newRollup(width int64) *rollup {
+	return &rollup{width: width, buckets: make(map[int64]map[string]*entry)}
+}
+
+// add folds an entry observed at ts into its bucket
+func (r *rollup) add(ts int64, e entry) {
+	start := ts - ts%r.width
+	bucket, exists := r.buckets[start]
+	if !exists {
+		bucket = make(map[string]*entry)
+		r.buckets[start] = bucket
+	}
+
+	key := monitor.SeriesKey(e.Name, e.Labels)
+	if acc, exists := bucket[key]; exists {
+		acc.Agg.merge(e.Agg)
+		return
+	}
+	bucket[key] = &entry{Name: e.Name, Labels: e.Labels, Agg: e.Agg}
+}
+
+// flush removes and returns the buckets that start before the bucket containing ts, oldest first
+func (r *rollup) flush(ts int64) []batch {
+	current := ts - ts%r.width
+
+	var starts []int64
+	for start := range r.buckets {
+		if start < current {
+			starts = append(starts, start)
+		}
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i] < starts[j] })
+
+	batches := make([]batch, 0, len(starts))
+	for _, start := range starts {
+		bucket := r.buckets[start]
+		keys := make([]string, 0, len(bucket))
+		for key := range bucket {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		b := batch{Timestamp: start, Entries: make([]entry, 0, len(keys))}
+		for _, key := range keys {
+			b.Entries = append(b.Entries, *bucket[key])
+		}
+		batches = append(batches, b)
+		delete(r.buckets, start)
+	}
+	return batches
+}
+
+// visitPending calls visit with a copy of every bucket that has not been flushed yet
+func (r *rollup) visitPending(visit func(batch)) {
+	for start, bucket := range r.buckets {
+		b := batch{Timestamp: start, Entries: make([]entry, 0, len(bucket))}
+		for _, e := range bucket {
+			b.Entries = append(b.Entries, *e)
+		}
+		visit(b)
+	}
+}
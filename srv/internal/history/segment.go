@@ -0,0 +1,400 @@
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/LissaiDev/Delphos/internal/monitor"
+)
+
+// Segment files are a sequence of frames: a little endian uint32 payload
+// length, the CRC-32 (IEEE) of the payload, then the payload itself.
+// The first payload byte is the record kind. Series are declared once per
+// segment and referenced by id from the sample records that follow.
+const (
+	recordSeries byte = 1 // id, name, label pairs
+	recordRaw    byte = 2 // timestamp, (id, value) pairs
+	recordRollup byte = 3 // bucket start, (id, min, max, sum, count, last) tuples
+
+	frameHeaderSize = 8
+	maxFrameSize    = 64 * 1024 * 1024
+	segmentSuffix   = ".seg"
+)
+
+// errCorruptRecord marks a frame that is truncated or fails its checksum
+var errCorruptRecord = errors.New("corrupt segment record")
+
+// seriesRef is a series declared in a segment
+type seriesRef struct {
+	name   string
+	labels map[string]string
+}
+
+// segment is an append-only file holding the records of one time window
+type segment struct {
+	path  string
+	start int64 // Window start in Unix milliseconds
+	end   int64 // Window end (exclusive) in Unix milliseconds
+	file  *os.File
+	ids   map[string]uint64 // Series key -> id declared in this segment
+	last  int64             // Timestamp of the newest record, 0 when empty
+	size  int64             // Offset just past the last complete frame
+}
+
+// segmentPath returns the file holding the window starting at start
+func segmentPath(dir string, start int64) string {
+	return filepath.Join(dir, fmt.Sprintf("%013d%s", start, segmentSuffix))
+}
+
+// listSegments returns the window starts of the segments in dir, oldest first
+func listSegments(dir string) ([]int64, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var starts []int64
+	for _, f := range files {
+		name := f.Name()
+		if f.IsDir() || !strings.HasSuffix(name, segmentSuffix) {
+			continue
+		}
+		start, err := strconv.ParseInt(strings.TrimSuffix(name, segmentSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		starts = append(starts, start)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i] < starts[j] })
+	return starts, nil
+}
+
+// openSegment opens (or creates) a segment for appending
+// Records after the last intact one are truncated away; truncated reports how many bytes were dropped
+func openSegment(dir string, start, window int64) (seg *segment, truncated int64, err error) {
+	path := segmentPath(dir, start)
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	seg = &segment{path: path, start: start, end: start + window, file: file, ids: make(map[string]uint64)}
+
+	valid, dict, err := scanSegment(file, func(b batch) {
+		if b.Timestamp > seg.last {
+			seg.last = b.Timestamp
+		}
+	})
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+	for id, ref := range dict {
+		seg.ids[monitor.SeriesKey(ref.name, ref.labels)] = id
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+	if info.Size() > valid {
+		truncated = info.Size() - valid
+		if err := file.Truncate(valid); err != nil {
+			file.Close()
+			return nil, 0, err
+		}
+	}
+	if _, err := file.Seek(valid, io.SeekStart); err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+	seg.size = valid
+
+	return seg, truncated, nil
+}
+
+// write appends a batch of the given kind, declaring unseen series first
+// The frames are written with a single call so a crash leaves at most one partial frame
+func (s *segment) write(kind byte, b batch) error {
+	var out bytes.Buffer
+	var payload []byte
+	var declared []string
+
+	for _, e := range b.Entries {
+		key := monitor.SeriesKey(e.Name, e.Labels)
+		if _, exists := s.ids[key]; exists {
+			continue
+		}
+		id := uint64(len(s.ids))
+		s.ids[key] = id
+		declared = append(declared, key)
+
+		payload = append(payload[:0], recordSeries)
+		payload = binary.AppendUvarint(payload, id)
+		payload = appendString(payload, e.Name)
+		payload = binary.AppendUvarint(payload, uint64(len(e.Labels)))
+		for _, k := range sortedKeys(e.Labels) {
+			payload = appendString(payload, k)
+			payload = appendString(payload, e.Labels[k])
+		}
+		appendFrame(&out, payload)
+	}
+
+	payload = append(payload[:0], kind)
+	payload = binary.AppendVarint(payload, b.Timestamp)
+	payload = binary.AppendUvarint(payload, uint64(len(b.Entries)))
+	for _, e := range b.Entries {
+		payload = binary.AppendUvarint(payload, s.ids[monitor.SeriesKey(e.Name, e.Labels)])
+		if kind == recordRaw {
+			payload = appendFloat(payload, e.Agg.Last)
+			continue
+		}
+		payload = appendFloat(payload, e.Agg.Min)
+		payload = appendFloat(payload, e.Agg.Max)
+		payload = appendFloat(payload, e.Agg.Sum)
+		payload = binary.AppendUvarint(payload, e.Agg.Count)
+		payload = appendFloat(payload, e.Agg.Last)
+	}
+	appendFrame(&out, payload)
+
+	if _, err := s.file.Write(out.Bytes()); err != nil {
+		// Drop whatever part of the frames reached the file so later
+		// records are not hidden behind a partial one, and declare the
+		// series again next time
+		if s.file.Truncate(s.size) == nil {
+			_, _ = s.file.Seek(s.size, io.SeekStart)
+		}
+		for _, key := range declared {
+			delete(s.ids, key)
+		}
+		return err
+	}
+	s.size += int64(out.Len())
+	if b.Timestamp > s.last {
+		s.last = b.Timestamp
+	}
+	return nil
+}
+
+// close flushes and closes the segment file
+func (s *segment) close() error {
+	if err := s.file.Sync(); err != nil {
+		s.file.Close()
+		return err
+	}
+	return s.file.Close()
+}
+
+// readSegment decodes every intact batch of the segment file at path
+// A missing file is treated as empty
+func readSegment(path string, visit func(batch)) error {
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer file.Close()
+
+	_, _, err = scanSegment(file, visit)
+	return err
+}
+
+// scanSegment decodes records from r until the end or the first corrupt frame
+// It returns the offset just past the last intact frame and the series dictionary
+func scanSegment(r io.Reader, visit func(batch)) (int64, map[uint64]seriesRef, error) {
+	reader := bufio.NewReader(r)
+	dict := make(map[uint64]seriesRef)
+	header := make([]byte, frameHeaderSize)
+	var valid int64
+
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return valid, dict, nil
+			}
+			return valid, dict, err
+		}
+
+		size := binary.LittleEndian.Uint32(header[0:4])
+		sum := binary.LittleEndian.Uint32(header[4:8])
+		if size == 0 || size > maxFrameSize {
+			return valid, dict, nil
+		}
+
+		payload := make([]byte, size)
+		if _, err := io.ReadFull(reader, payload); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return valid, dict, nil
+			}
+			return valid, dict, err
+		}
+		if crc32.ChecksumIEEE(payload) != sum {
+			return valid, dict, nil
+		}
+
+		b, err := decodeRecord(payload, dict)
+		if err != nil {
+			return valid, dict, nil
+		}
+		valid += frameHeaderSize + int64(size)
+
+		if b != nil && visit != nil {
+			visit(*b)
+		}
+	}
+}
+
+// decodeRecord decodes one payload, registering series declarations in dict
+// Sample records are returned as a batch; declarations return nil
+func decodeRecord(payload []byte, dict map[uint64]seriesRef) (*batch, error) {
+	d := &decoder{buf: payload[1:]}
+
+	switch payload[0] {
+	case recordSeries:
+		id := d.uvarint()
+		ref := seriesRef{name: d.string()}
+		if n := d.uvarint(); n > 0 && d.err == nil {
+			ref.labels = make(map[string]string, n)
+			for i := uint64(0); i < n && d.err == nil; i++ {
+				k := d.string()
+				ref.labels[k] = d.string()
+			}
+		}
+		if d.err != nil {
+			return nil, d.err
+		}
+		dict[id] = ref
+		return nil, nil
+
+	case recordRaw, recordRollup:
+		b := &batch{Timestamp: d.varint()}
+		n := d.uvarint()
+		for i := uint64(0); i < n && d.err == nil; i++ {
+			ref, exists := dict[d.uvarint()]
+			if !exists {
+				return nil, errCorruptRecord
+			}
+			var agg aggregate
+			if payload[0] == recordRaw {
+				agg = newAggregate(d.float())
+			} else {
+				agg.Min = d.float()
+				agg.Max = d.float()
+				agg.Sum = d.float()
+				agg.Count = d.uvarint()
+				agg.Last = d.float()
+			}
+			b.Entries = append(b.Entries, entry{Name: ref.name, Labels: ref.labels, Agg: agg})
+		}
+		if d.err != nil {
+			return nil, d.err
+		}
+		return b, nil
+	}
+
+	return nil, errCorruptRecord
+}
+
+// decoder reads record fields, latching the first error
+type decoder struct {
+	buf []byte
+	err error
+}
+
+func (d *decoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		d.err = errCorruptRecord
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *decoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.buf)
+	if n <= 0 {
+		d.err = errCorruptRecord
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *decoder) float() float64 {
+	if d.err != nil {
+		return 0
+	}
+	if len(d.buf) < 8 {
+		d.err = errCorruptRecord
+		return 0
+	}
+	v := math.Float64frombits(binary.LittleEndian.Uint64(d.buf))
+	d.buf = d.buf[8:]
+	return v
+}
+
+func (d *decoder) string() string {
+	n := d.uvarint()
+	if d.err != nil {
+		return ""
+	}
+	if uint64(len(d.buf)) < n {
+		d.err = errCorruptRecord
+		return ""
+	}
+	v := string(d.buf[:n])
+	d.buf = d.buf[n:]
+	return v
+}
+
+// appendFrame writes payload to out preceded by its length and checksum
+func appendFrame(out *bytes.Buffer, payload []byte) {
+	var header [frameHeaderSize]byte
+	binary.LittleEndian.PutUint32(header[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(header[4:8], crc32.ChecksumIEEE(payload))
+	out.Write(header[:])
+	out.Write(payload)
+}
+
+func appendString(buf []byte, s string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
+
+func appendFloat(buf []byte, v float64) []byte {
+	return binary.LittleEndian.AppendUint64(buf, math.Float64bits(v))
+}
+
+// sortedKeys returns the keys of labels in order
+func sortedKeys(labels map[string]string) []string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
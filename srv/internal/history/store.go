@@ -0,0 +1,430 @@
+package history
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/LissaiDev/Delphos/internal/config"
+	"github.com/LissaiDev/Delphos/internal/monitor"
+	"github.com/LissaiDev/Delphos/pkg/logger"
+)
+
+// Approximate memory cost used to enforce the budget
+const (
+	pointBytes  = 16  // Timestamp and value of a stored point
+	seriesBytes = 256 // Fixed overhead of a series (map entry, slice header, name)
+)
+
+// series holds the points of a single metric and label set, oldest first
+type series struct {
+	name   string
+	labels map[string]string
+	points []Point
+}
+
+// storedPoint is a point read back for a query, raw or rolled up
+type storedPoint struct {
+	Timestamp int64
+	Agg       aggregate
+}
+
+// storedSeries is a series read back for a query
+type storedSeries struct {
+	labels map[string]string
+	points []storedPoint
+}
+
+// sort orders the points by timestamp
+func (s *storedSeries) sort() {
+	sort.SliceStable(s.points, func(i, j int) bool {
+		return s.points[i].Timestamp < s.points[j].Timestamp
+	})
+}
+
+// Store keeps recent samples at full resolution in memory and, when a
+// DiskStore is attached, persists them with 1m and 1h rollups
+// Samples older than the retention are dropped from memory, and when the
+// approximate memory use exceeds the budget the oldest samples are evicted first
+type Store struct {
+	logger    logger.BasicLogger
+	retention time.Duration
+	budget    int64
+	disk      *DiskStore
+	series    map[string]*series
+	points    int
+	mu        sync.RWMutex
+}
+
+var (
+	storeInstance *Store
+	once          sync.Once
+)
+
+// New creates a history store
+// budget is the approximate upper bound for in-memory data in bytes; disk may be nil
+func New(log logger.BasicLogger, retention time.Duration, budget int64, disk *DiskStore) *Store {
+	return &Store{
+		logger:    log,
+		retention: retention,
+		budget:    budget,
+		disk:      disk,
+		series:    make(map[string]*series),
+	}
+}
+
+// GetInstance returns the shared store configured from config.Env
+// Persistence is skipped (with an error logged) when the data directory cannot be opened
+func GetInstance() *Store {
+	once.Do(func() {
+		log := logger.GetInstance()
+
+		var disk *DiskStore
+		if config.Env.DataDir != "" {
+			opened, err := OpenDisk(
+				log,
+				config.Env.DataDir,
+				time.Duration(config.Env.RetentionRaw)*time.Second,
+				time.Duration(config.Env.Retention1m)*time.Second,
+				time.Duration(config.Env.Retention1h)*time.Second,
+			)
+			if err != nil {
+				log.Error("Failed to open metric storage, history will not persist", map[string]interface{}{
+					"data_dir": config.Env.DataDir,
+					"error":    err.Error(),
+				})
+			} else {
+				disk = opened
+			}
+		}
+
+		storeInstance = New(
+			log,
+			time.Duration(config.Env.HistoryRetention)*time.Second,
+			int64(config.Env.HistoryMemoryMB)*1024*1024,
+			disk,
+		)
+	})
+	return storeInstance
+}
+
+// Enabled reports whether the store keeps any history
+func (s *Store) Enabled() bool {
+	return s.memoryEnabled() || s.disk != nil
+}
+
+// memoryEnabled reports whether samples are kept in memory
+func (s *Store) memoryEnabled() bool {
+	return s.retention > 0 && s.budget > 0
+}
+
+// Append records a batch of samples taken at the given time
+// Counters are left out since their rates are recorded, and samples older
+// than the newest in-memory point of their series are ignored
+func (s *Store) Append(at time.Time, samples []monitor.Sample) {
+	gauges := make([]monitor.Sample, 0, len(samples))
+	for _, sample := range samples {
+		if sample.Type != monitor.MetricCounter {
+			gauges = append(gauges, sample)
+		}
+	}
+	samples = gauges
+
+	if s.disk != nil {
+		// Failures are logged by the disk store; the in-memory copy is still kept
+		_ = s.disk.Append(at, samples)
+	}
+
+	if !s.memoryEnabled() {
+		return
+	}
+
+	ts := at.UnixMilli()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, sample := range samples {
+		key := sample.Key()
+		sr, exists := s.series[key]
+		if !exists {
+			sr = &series{name: sample.Name, labels: sample.Labels}
+			s.series[key] = sr
+		}
+		if n := len(sr.points); n > 0 && sr.points[n-1].Timestamp >= ts {
+			continue
+		}
+		sr.points = append(sr.points, Point{Timestamp: ts, Value: sample.Value})
+		s.points++
+	}
+
+	s.dropBefore(at.Add(-s.retention).UnixMilli())
+	s.enforceBudget()
+}
+
+// Close releases the on-disk storage
+func (s *Store) Close() error {
+	if s.disk == nil {
+		return nil
+	}
+	return s.disk.Close()
+}
+
+// Query returns the series of a metric aligned on step boundaries
+// The finest resolution still holding data from q.From is used, preferring the
+// coarsest one that is no wider than the step. When only a resolution coarser
+// than the step covers the range, the step is widened to match it.
+func (s *Store) Query(q Query) (*QueryResult, error) {
+	if q.Metric == "" {
+		return nil, ErrMissingMetric
+	}
+	if q.Step < time.Second || q.Step%time.Second != 0 {
+		return nil, ErrInvalidStep
+	}
+	if q.To.Before(q.From) {
+		return nil, ErrInvalidRange
+	}
+	if q.Aggregate == "" {
+		q.Aggregate = AggregateAvg
+	}
+	if q.Aggregate != AggregateAvg && q.Aggregate != AggregateMin && q.Aggregate != AggregateMax && q.Aggregate != AggregateLast {
+		return nil, ErrInvalidAggregate
+	}
+
+	res := s.resolve(q.From, q.Step, time.Now())
+	if res != nil && res.width > q.Step {
+		q.Step = res.width
+	}
+
+	stepMs := q.Step.Milliseconds()
+	start := q.From.UnixMilli()
+	start -= start % stepMs
+	end := q.To.UnixMilli()
+	steps := (end-start)/stepMs + 1
+	if steps > MaxQueryPoints {
+		return nil, ErrTooManyPoints
+	}
+
+	result := &QueryResult{
+		Metric:     q.Metric,
+		Resolution: ResolutionRaw,
+		Aggregate:  q.Aggregate,
+		From:       start / 1000,
+		To:         (start + (steps-1)*stepMs) / 1000,
+		Step:       stepMs / 1000,
+		Timestamps: make([]int64, steps),
+		Series:     []*Series{},
+	}
+	for i := range result.Timestamps {
+		result.Timestamps[i] = (start + int64(i)*stepMs) / 1000
+	}
+
+	var found map[string]*storedSeries
+	if res == nil || (res == s.disk.raw && s.memoryCovers(q.From)) {
+		found = s.readMemory(q.Metric, q.Matchers, start, start+steps*stepMs)
+	} else {
+		result.Resolution = res.name
+		var err error
+		found, err = s.disk.Read(res, q.Metric, q.Matchers, time.UnixMilli(start), time.UnixMilli(start+steps*stepMs-1))
+		if err != nil {
+			s.logger.Error("Failed to read metric storage", map[string]interface{}{
+				"resolution": res.name,
+				"metric":     q.Metric,
+				"error":      err.Error(),
+			})
+			return nil, err
+		}
+	}
+
+	keys := make([]string, 0, len(found))
+	for key := range found {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		sr := found[key]
+		values := bucket(sr.points, start, stepMs, steps, q.Aggregate)
+		if values == nil {
+			continue
+		}
+		result.Series = append(result.Series, &Series{Labels: sr.labels, Values: values})
+	}
+
+	return result, nil
+}
+
+// Metrics returns the names of all metrics currently held in memory, sorted
+func (s *Store) Metrics() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	names := make([]string, 0)
+	for _, sr := range s.series {
+		if !seen[sr.name] {
+			seen[sr.name] = true
+			names = append(names, sr.name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Usage returns the number of series and points held in memory and their approximate size in bytes
+func (s *Store) Usage() (series int, points int, bytes int64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.series), s.points, s.usage()
+}
+
+// resolve picks the stored resolution for a query starting at from
+// Returns nil when only the in-memory history is available
+func (s *Store) resolve(from time.Time, step time.Duration, now time.Time) *resolution {
+	if s.disk == nil {
+		return nil
+	}
+
+	var choice *resolution
+	for _, res := range s.disk.resolutions() {
+		if !res.covers(from, now) {
+			continue
+		}
+		if choice == nil || res.width <= step {
+			choice = res
+		}
+	}
+	if choice == nil {
+		choice = s.disk.hour
+	}
+	return choice
+}
+
+// memoryCovers reports whether the in-memory history still holds data from t
+func (s *Store) memoryCovers(t time.Time) bool {
+	if !s.memoryEnabled() {
+		return false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	oldest := int64(-1)
+	for _, sr := range s.series {
+		if ts := sr.points[0].Timestamp; oldest < 0 || ts < oldest {
+			oldest = ts
+		}
+	}
+	return oldest >= 0 && oldest <= t.UnixMilli()
+}
+
+// readMemory copies the in-memory points of the matching series within [from, to)
+func (s *Store) readMemory(metric string, matchers map[string]string, from, to int64) map[string]*storedSeries {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	found := make(map[string]*storedSeries)
+	for key, sr := range s.series {
+		if sr.name != metric || !matches(sr.labels, matchers) {
+			continue
+		}
+
+		i := sort.Search(len(sr.points), func(i int) bool {
+			return sr.points[i].Timestamp >= from
+		})
+		stored := &storedSeries{labels: sr.labels}
+		for ; i < len(sr.points) && sr.points[i].Timestamp < to; i++ {
+			stored.points = append(stored.points, storedPoint{
+				Timestamp: sr.points[i].Timestamp,
+				Agg:       newAggregate(sr.points[i].Value),
+			})
+		}
+		found[key] = stored
+	}
+	return found
+}
+
+// usage returns the approximate memory held by the store; callers hold the lock
+func (s *Store) usage() int64 {
+	return int64(s.points)*pointBytes + int64(len(s.series))*seriesBytes
+}
+
+// dropBefore removes points older than cutoff and series left empty; callers hold the lock
+func (s *Store) dropBefore(cutoff int64) {
+	for key, sr := range s.series {
+		i := sort.Search(len(sr.points), func(i int) bool {
+			return sr.points[i].Timestamp >= cutoff
+		})
+		if i == 0 {
+			continue
+		}
+		sr.points = sr.points[i:]
+		s.points -= i
+		if len(sr.points) == 0 {
+			delete(s.series, key)
+		}
+	}
+}
+
+// enforceBudget evicts the oldest samples until the store fits its budget; callers hold the lock
+func (s *Store) enforceBudget() {
+	if s.usage() <= s.budget {
+		return
+	}
+
+	before := s.points
+	for s.usage() > s.budget && len(s.series) > 0 {
+		oldest := int64(-1)
+		for _, sr := range s.series {
+			if ts := sr.points[0].Timestamp; oldest < 0 || ts < oldest {
+				oldest = ts
+			}
+		}
+		s.dropBefore(oldest + 1)
+	}
+
+	s.logger.Debug("History memory budget reached, evicted oldest samples", map[string]interface{}{
+		"evicted_points": before - s.points,
+		"points":         s.points,
+		"series":         len(s.series),
+		"budget_bytes":   s.budget,
+	})
+}
+
+// matches reports whether labels carry every matcher value
+func matches(labels, matchers map[string]string) bool {
+	for k, v := range matchers {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// bucket merges points into steps starting at start and reduces each step with fn
+// Returns nil when no point falls within the range
+func bucket(points []storedPoint, start, stepMs, steps int64, fn string) []*float64 {
+	end := start + steps*stepMs
+	i := sort.Search(len(points), func(i int) bool {
+		return points[i].Timestamp >= start
+	})
+	if i == len(points) || points[i].Timestamp >= end {
+		return nil
+	}
+
+	aggs := make([]aggregate, steps)
+	for ; i < len(points) && points[i].Timestamp < end; i++ {
+		idx := (points[i].Timestamp - start) / stepMs
+		aggs[idx].merge(points[i].Agg)
+	}
+
+	values := make([]*float64, steps)
+	for idx := range values {
+		if aggs[idx].Count > 0 {
+			v := aggs[idx].value(fn)
+			values[idx] = &v
+		}
+	}
+	return values
+}
@@ -52,13 +52,14 @@ type BasicLogger interface {
 	Error(message string, fields map[string]interface{})
 }
 
-// SystemMonitor defines the contract for system monitoring
-type SystemMonitor interface {
-	GetHostInfo() (*monitor.Host, error)
-	GetMemoryInfo() (*monitor.Memory, error)
-	GetCPUInfo() ([]*monitor.CPU, error)
-	GetDiskInfo() ([]*monitor.Disk, error)
-	GetNetworkInfo() ([]*monitor.Network, error)
+// CollectorRegistry defines the contract for registering system collectors
+type CollectorRegistry interface {
+	Register(collector monitor.Collector) error
+	Unregister(name string) error
+	Enable(name string) error
+	Disable(name string)
+	Enabled(name string) bool
+	Collectors() []monitor.Collector
 }
 
 // ServerRunner defines the contract for server management
@@ -0,0 +1,141 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Collector gathers one kind of system information
+// The value returned by Collect is emitted under Name() in the Monitor payload.
+// Collect should return promptly once ctx is done; the stats service stops
+// waiting for it at the deadline either way.
+type Collector interface {
+	Name() string
+	Collect(ctx context.Context) (interface{}, error)
+}
+
+// TimeoutCollector is implemented by collectors that need a deadline other
+// than the configured default
+type TimeoutCollector interface {
+	Collector
+	Timeout() time.Duration
+}
+
+// Collector registry errors
+var (
+	ErrCollectorExists   = errors.New("collector already registered")
+	ErrCollectorNotFound = errors.New("collector not registered")
+	ErrInvalidCollector  = errors.New("invalid collector")
+)
+
+// Registry holds the collectors run on every stats collection
+// Collectors run in registration order and can be enabled or disabled by name
+type Registry struct {
+	collectors map[string]Collector
+	order      []string
+	disabled   map[string]bool
+	mu         sync.RWMutex
+}
+
+// NewRegistry creates an empty collector registry
+func NewRegistry() *Registry {
+	return &Registry{
+		collectors: make(map[string]Collector),
+		disabled:   make(map[string]bool),
+	}
+}
+
+// Register adds a collector to the registry
+// Names must be unique; disabling a name before it is registered is honoured
+func (r *Registry) Register(collector Collector) error {
+	if collector == nil || collector.Name() == "" {
+		return ErrInvalidCollector
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	name := collector.Name()
+	if _, exists := r.collectors[name]; exists {
+		return ErrCollectorExists
+	}
+
+	r.collectors[name] = collector
+	r.order = append(r.order, name)
+	return nil
+}
+
+// Unregister removes a collector from the registry
+func (r *Registry) Unregister(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.collectors[name]; !exists {
+		return ErrCollectorNotFound
+	}
+
+	delete(r.collectors, name)
+	for i, n := range r.order {
+		if n == name {
+			r.order = append(r.order[:i], r.order[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// Enable turns a collector on
+func (r *Registry) Enable(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.collectors[name]; !exists {
+		return ErrCollectorNotFound
+	}
+	delete(r.disabled, name)
+	return nil
+}
+
+// Disable turns a collector off without removing it
+// Unknown names are remembered so configuration can disable collectors registered later
+func (r *Registry) Disable(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.disabled[name] = true
+}
+
+// Enabled reports whether a collector is registered and enabled
+func (r *Registry) Enabled(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, exists := r.collectors[name]
+	return exists && !r.disabled[name]
+}
+
+// Collectors returns the enabled collectors in registration order
+func (r *Registry) Collectors() []Collector {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	collectors := make([]Collector, 0, len(r.order))
+	for _, name := range r.order {
+		if !r.disabled[name] {
+			collectors = append(collectors, r.collectors[name])
+		}
+	}
+	return collectors
+}
+
+// Names returns the names of all registered collectors in registration order
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, len(r.order))
+	copy(names, r.order)
+	return names
+}
@@ -0,0 +1,145 @@
+package monitor
+
+import (
+	"context"
+	"time"
+
+	"github.com/LissaiDev/Delphos/internal/config"
+)
+
+// Built-in collector names, matching their keys in the Monitor payload
+const (
+	CollectorHost      = "host"
+	CollectorMemory    = "memory"
+	CollectorCPU       = "cpu"
+	CollectorLoad      = "load"
+	CollectorDisk      = "disk"
+	CollectorDiskIO    = "diskIO"
+	CollectorNetwork   = "network"
+	CollectorProcesses = "processes"
+)
+
+// NewDefaultRegistry creates a registry holding the built-in collectors
+// Collectors listed in config.Env.DisabledCollectors start disabled
+func NewDefaultRegistry() *Registry {
+	registry := NewRegistry()
+
+	for _, name := range config.Env.DisabledCollectors {
+		registry.Disable(name)
+	}
+
+	for _, collector := range []Collector{
+		&hostCollector{},
+		&memoryCollector{},
+		&cpuCollector{tracker: NewCPUTimesTracker()},
+		&loadCollector{tracker: NewLoadRateTracker()},
+		&diskCollector{},
+		&diskIOCollector{tracker: NewDiskIORateTracker()},
+		&networkCollector{tracker: NewNetworkRateTracker()},
+		&processCollector{},
+	} {
+		_ = registry.Register(collector)
+	}
+
+	return registry
+}
+
+// hostCollector collects host information
+type hostCollector struct{}
+
+func (c *hostCollector) Name() string { return CollectorHost }
+
+func (c *hostCollector) Collect(ctx context.Context) (interface{}, error) {
+	return GetHostInfoWithContext(ctx)
+}
+
+// memoryCollector collects memory statistics
+type memoryCollector struct{}
+
+func (c *memoryCollector) Name() string { return CollectorMemory }
+
+func (c *memoryCollector) Collect(ctx context.Context) (interface{}, error) {
+	return GetMemoryInfoWithContext(ctx)
+}
+
+// cpuCollector collects per-core CPU usage over the collection interval
+type cpuCollector struct {
+	tracker *CPUTimesTracker
+}
+
+func (c *cpuCollector) Name() string { return CollectorCPU }
+
+func (c *cpuCollector) Collect(ctx context.Context) (interface{}, error) {
+	cpus, err := GetCPUInfoWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.tracker.Apply(cpus, time.Now())
+	return cpus, nil
+}
+
+// loadCollector collects load averages and scheduler rates
+type loadCollector struct {
+	tracker *LoadRateTracker
+}
+
+func (c *loadCollector) Name() string { return CollectorLoad }
+
+func (c *loadCollector) Collect(ctx context.Context) (interface{}, error) {
+	load, err := GetLoadInfoWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.tracker.Apply(load, time.Now())
+	return load, nil
+}
+
+// diskCollector collects disk space usage per partition
+type diskCollector struct{}
+
+func (c *diskCollector) Name() string { return CollectorDisk }
+
+func (c *diskCollector) Collect(ctx context.Context) (interface{}, error) {
+	return GetDiskInfoWithContext(ctx)
+}
+
+// diskIOCollector collects block device I/O rates
+type diskIOCollector struct {
+	tracker *DiskIORateTracker
+}
+
+func (c *diskIOCollector) Name() string { return CollectorDiskIO }
+
+func (c *diskIOCollector) Collect(ctx context.Context) (interface{}, error) {
+	devices, err := GetDiskIOInfoWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.tracker.Apply(devices, time.Now())
+	return devices, nil
+}
+
+// networkCollector collects network interface counters and rates
+type networkCollector struct {
+	tracker *NetworkRateTracker
+}
+
+func (c *networkCollector) Name() string { return CollectorNetwork }
+
+func (c *networkCollector) Collect(ctx context.Context) (interface{}, error) {
+	networks, err := GetNetworkInfoWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.tracker.Apply(networks, time.Now())
+	return networks, nil
+}
+
+// processCollector collects the top processes by CPU usage
+type processCollector struct{}
+
+func (c *processCollector) Name() string { return CollectorProcesses }
+
+func (c *processCollector) Collect(ctx context.Context) (interface{}, error) {
+	return GetProcessInfoWithContext(ctx, config.Env.ProcessLimit, ProcessSortCPU)
+}
@@ -1,45 +1,69 @@
 package monitor
 
 import (
+	"context"
+	"sync"
+	"time"
+
 	"github.com/LissaiDev/Delphos/pkg/logger"
 	"github.com/shirou/gopsutil/v4/cpu"
 )
 
 // GetCPUInfo returns CPU information for the system
 func GetCPUInfo() ([]*CPU, error) {
-	return getCPUInfoWithLogger()
+	return GetCPUInfoWithContext(context.Background())
+}
+
+// GetCPUInfoWithContext returns CPU information for the system, honouring ctx cancellation
+func GetCPUInfoWithContext(ctx context.Context) ([]*CPU, error) {
+	return getCPUInfoWithLogger(ctx)
 }
 
 // getCPUInfoWithLogger returns CPU information using the provided logger
-func getCPUInfoWithLogger() ([]*CPU, error) {
+// Usage and the time breakdown are computed since boot; CPUTimesTracker
+// replaces them with values over the collection interval
+func getCPUInfoWithLogger(ctx context.Context) ([]*CPU, error) {
 	log := logger.GetInstance()
 
 	log.Debug("Starting CPU information collection")
 
 	var cpus []*CPU
 
-	// Get CPU usage percentages
-	log.Debug("Collecting CPU usage percentages")
-	percent, err := cpu.Percent(0, true)
+	// Get cumulative CPU times per core
+	log.Debug("Collecting CPU times")
+	times, err := cpu.TimesWithContext(ctx, true)
 	if err != nil {
-		log.Error("Failed to collect CPU usage percentages", map[string]interface{}{
+		log.Error("Failed to collect CPU times", map[string]interface{}{
 			"error": err.Error(),
 		})
 		return nil, err
 	}
 
-	log.Debug("CPU usage percentages collected", map[string]interface{}{
-		"cpu_count":    len(percent),
-		"usage_values": percent,
+	log.Debug("CPU times collected", map[string]interface{}{
+		"cpu_count": len(times),
 	})
 
-	for _, usage := range percent {
-		cpus = append(cpus, &CPU{Usage: usage})
+	for _, t := range times {
+		c := &CPU{
+			Name: t.CPU,
+			Times: &CPUTimes{
+				User:    t.User,
+				System:  t.System,
+				Nice:    t.Nice,
+				Idle:    t.Idle,
+				Iowait:  t.Iowait,
+				Irq:     t.Irq,
+				Softirq: t.Softirq,
+				Steal:   t.Steal,
+			},
+		}
+		c.applyBreakdown(c.Times)
+		cpus = append(cpus, c)
 	}
 
 	// Get CPU detailed information
 	log.Debug("Collecting CPU detailed information")
-	info, err := cpu.Info()
+	info, err := cpu.InfoWithContext(ctx)
 	if err != nil {
 		log.Error("Failed to collect CPU detailed information", map[string]interface{}{
 			"error": err.Error(),
@@ -68,16 +92,120 @@ func getCPUInfoWithLogger() ([]*CPU, error) {
 	log.Debug("CPU information collection completed", map[string]interface{}{
 		"total_cpus": len(cpus),
 		"avg_usage": func() float64 {
-			if len(percent) == 0 {
+			if len(cpus) == 0 {
 				return 0
 			}
 			sum := 0.0
-			for _, p := range percent {
-				sum += p
+			for _, c := range cpus {
+				sum += c.Usage
 			}
-			return sum / float64(len(percent))
+			return sum / float64(len(cpus))
 		}(),
 	})
 
 	return cpus, nil
 }
+
+// total returns the sum of all CPU time modes
+func (t *CPUTimes) total() float64 {
+	return t.User + t.System + t.Nice + t.Idle + t.Iowait + t.Irq + t.Softirq + t.Steal
+}
+
+// sub returns the per-mode difference between two samples, clamped at zero
+func (t *CPUTimes) sub(prev *CPUTimes) *CPUTimes {
+	clamp := func(v float64) float64 {
+		if v < 0 {
+			return 0
+		}
+		return v
+	}
+	return &CPUTimes{
+		User:    clamp(t.User - prev.User),
+		System:  clamp(t.System - prev.System),
+		Nice:    clamp(t.Nice - prev.Nice),
+		Idle:    clamp(t.Idle - prev.Idle),
+		Iowait:  clamp(t.Iowait - prev.Iowait),
+		Irq:     clamp(t.Irq - prev.Irq),
+		Softirq: clamp(t.Softirq - prev.Softirq),
+		Steal:   clamp(t.Steal - prev.Steal),
+	}
+}
+
+// applyBreakdown sets the usage and per-mode percentages from a span of CPU time
+func (c *CPU) applyBreakdown(span *CPUTimes) {
+	total := span.total()
+	if total <= 0 {
+		return
+	}
+
+	c.User = span.User / total * 100
+	c.System = span.System / total * 100
+	c.Nice = span.Nice / total * 100
+	c.Idle = span.Idle / total * 100
+	c.Iowait = span.Iowait / total * 100
+	c.Irq = span.Irq / total * 100
+	c.Softirq = span.Softirq / total * 100
+	c.Steal = span.Steal / total * 100
+	c.Usage = 100 - c.Idle - c.Iowait
+	if c.Usage < 0 {
+		c.Usage = 0
+	}
+}
+
+// cpuSample holds the CPU times observed for a core on the previous collection
+type cpuSample struct {
+	times CPUTimes
+	at    time.Time
+}
+
+// CPUTimesTracker keeps the previous CPU times of each core and computes
+// usage and the per-mode breakdown over the collection interval
+type CPUTimesTracker struct {
+	previous map[string]cpuSample
+	mu       sync.Mutex
+}
+
+// NewCPUTimesTracker creates an empty CPU times tracker
+func NewCPUTimesTracker() *CPUTimesTracker {
+	return &CPUTimesTracker{
+		previous: make(map[string]cpuSample),
+	}
+}
+
+// Apply replaces the since-boot percentages of each core with percentages
+// over the interval since the previous sample. Cores seen for the first time
+// keep their since-boot values and report a zero interval.
+func (t *CPUTimesTracker) Apply(cpus []*CPU, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	seen := make(map[string]bool, len(cpus))
+
+	for _, c := range cpus {
+		if c.Times == nil {
+			continue
+		}
+		seen[c.Name] = true
+
+		prev, ok := t.previous[c.Name]
+		t.previous[c.Name] = cpuSample{times: *c.Times, at: at}
+		if !ok {
+			continue
+		}
+
+		elapsed := at.Sub(prev.at).Seconds()
+		span := c.Times.sub(&prev.times)
+		if elapsed <= 0 || span.total() <= 0 {
+			continue
+		}
+
+		c.Interval = elapsed
+		c.applyBreakdown(span)
+	}
+
+	for name := range t.previous {
+		if !seen[name] {
+			delete(t.previous, name)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package monitor
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
 
 // Host represents system host information
 type Host struct {
@@ -20,10 +24,49 @@ type Memory struct {
 }
 
 // CPU represents CPU information and usage statistics
+// Percentages are computed from CPU time deltas over Interval seconds
+// (since boot on the first sample, when Interval is 0)
 type CPU struct {
-	Usage float64 `json:"usage"` // CPU usage percentage
-	Model string  `json:"model"` // CPU model name
-	Cores int     `json:"cores"` // Number of CPU cores
+	Name     string    `json:"name"`     // Logical CPU name (e.g. cpu0)
+	Usage    float64   `json:"usage"`    // CPU usage percentage (all modes except idle and iowait)
+	Model    string    `json:"model"`    // CPU model name
+	Cores    int       `json:"cores"`    // Number of CPU cores
+	User     float64   `json:"user"`     // Time spent in user mode in percentage
+	System   float64   `json:"system"`   // Time spent in kernel mode in percentage
+	Nice     float64   `json:"nice"`     // Time spent on niced processes in percentage
+	Idle     float64   `json:"idle"`     // Idle time in percentage
+	Iowait   float64   `json:"iowait"`   // Time spent waiting for I/O in percentage
+	Irq      float64   `json:"irq"`      // Time spent servicing hardware interrupts in percentage
+	Softirq  float64   `json:"softirq"`  // Time spent servicing software interrupts in percentage
+	Steal    float64   `json:"steal"`    // Time stolen by the hypervisor in percentage
+	Times    *CPUTimes `json:"times"`    // Cumulative CPU times since boot
+	Interval float64   `json:"interval"` // Sample interval used for the percentages in seconds
+}
+
+// CPUTimes represents cumulative CPU time per mode in seconds
+type CPUTimes struct {
+	User    float64 `json:"user"`    // Seconds in user mode
+	System  float64 `json:"system"`  // Seconds in kernel mode
+	Nice    float64 `json:"nice"`    // Seconds on niced processes
+	Idle    float64 `json:"idle"`    // Seconds idle
+	Iowait  float64 `json:"iowait"`  // Seconds waiting for I/O
+	Irq     float64 `json:"irq"`     // Seconds servicing hardware interrupts
+	Softirq float64 `json:"softirq"` // Seconds servicing software interrupts
+	Steal   float64 `json:"steal"`   // Seconds stolen by the hypervisor
+}
+
+// Load represents load averages and system-wide scheduler activity
+type Load struct {
+	Load1                 float64 `json:"load1"`                 // 1 minute load average
+	Load5                 float64 `json:"load5"`                 // 5 minute load average
+	Load15                float64 `json:"load15"`                // 15 minute load average
+	ProcsRunning          int     `json:"procsRunning"`          // Processes currently runnable
+	ProcsBlocked          int     `json:"procsBlocked"`          // Processes blocked on I/O
+	TotalContextSwitches  uint64  `json:"totalContextSwitches"`  // Context switches since boot
+	TotalInterrupts       uint64  `json:"totalInterrupts"`       // Interrupts serviced since boot
+	ContextSwitchesPerSec float64 `json:"contextSwitchesPerSec"` // Context switches per second
+	InterruptsPerSec      float64 `json:"interruptsPerSec"`      // Interrupts per second
+	Interval              float64 `json:"interval"`              // Sample interval used for the rates in seconds (0 on first sample)
 }
 
 // Disk represents disk partition information and usage statistics
@@ -34,23 +77,218 @@ type Disk struct {
 	Used        float64 `json:"used"`        // Used disk space in bytes
 	Free        float64 `json:"free"`        // Free disk space in bytes
 	UsedPercent float64 `json:"usedPercent"` // Disk usage percentage
+
+	InodesTotal       float64 `json:"inodesTotal"`       // Total inodes (0 when the file system does not report them)
+	InodesUsed        float64 `json:"inodesUsed"`        // Used inodes
+	InodesFree        float64 `json:"inodesFree"`        // Free inodes
+	InodesUsedPercent float64 `json:"inodesUsedPercent"` // Inode usage percentage
+}
+
+// DiskIO represents block device I/O statistics
+// Total* fields are cumulative counters; rate fields are computed against
+// the previous sample of the same device over Interval seconds
+type DiskIO struct {
+	Device           string   `json:"device"`           // Block device name (e.g. sda)
+	Mountpoints      []string `json:"mountpoints"`      // Mount points backed by this device
+	TotalReadBytes   uint64   `json:"totalReadBytes"`   // Total bytes read
+	TotalWriteBytes  uint64   `json:"totalWriteBytes"`  // Total bytes written
+	TotalReadCount   uint64   `json:"totalReadCount"`   // Total completed reads
+	TotalWriteCount  uint64   `json:"totalWriteCount"`  // Total completed writes
+	TotalReadTimeMs  uint64   `json:"totalReadTimeMs"`  // Total time spent reading in milliseconds
+	TotalWriteTimeMs uint64   `json:"totalWriteTimeMs"` // Total time spent writing in milliseconds
+	TotalIOTimeMs    uint64   `json:"totalIoTimeMs"`    // Total time with I/O in flight in milliseconds
+	ReadBytesPerSec  float64  `json:"readBytesPerSec"`  // Bytes read per second
+	WriteBytesPerSec float64  `json:"writeBytesPerSec"` // Bytes written per second
+	ReadIOPS         float64  `json:"readIops"`         // Reads completed per second
+	WriteIOPS        float64  `json:"writeIops"`        // Writes completed per second
+	AwaitMs          float64  `json:"awaitMs"`          // Average time per operation in milliseconds
+	UtilPercent      float64  `json:"utilPercent"`      // Share of time the device was busy
+	Interval         float64  `json:"interval"`         // Sample interval used for the rates in seconds (0 on first sample)
 }
 
 // Network represents network interface statistics
+// Total* fields are cumulative counters; *PerSec fields are rates computed
+// against the previous sample of the same interface over Interval seconds
 type Network struct {
-	TotalBytesSent uint64 `json:"totalBytesSent"` // Total bytes sent
-	TotalBytesRecv uint64 `json:"totalBytesRecv"` // Total bytes received
-	InterfaceName  string `json:"interfaceName"`  // Network interface name
+	TotalBytesSent    uint64  `json:"totalBytesSent"`    // Total bytes sent
+	TotalBytesRecv    uint64  `json:"totalBytesRecv"`    // Total bytes received
+	TotalPacketsSent  uint64  `json:"totalPacketsSent"`  // Total packets sent
+	TotalPacketsRecv  uint64  `json:"totalPacketsRecv"`  // Total packets received
+	TotalErrorsIn     uint64  `json:"totalErrorsIn"`     // Total errors while receiving
+	TotalErrorsOut    uint64  `json:"totalErrorsOut"`    // Total errors while sending
+	TotalDropsIn      uint64  `json:"totalDropsIn"`      // Total incoming packets dropped
+	TotalDropsOut     uint64  `json:"totalDropsOut"`     // Total outgoing packets dropped
+	InterfaceName     string  `json:"interfaceName"`     // Network interface name
+	BytesSentPerSec   float64 `json:"bytesSentPerSec"`   // Bytes sent per second
+	BytesRecvPerSec   float64 `json:"bytesRecvPerSec"`   // Bytes received per second
+	PacketsSentPerSec float64 `json:"packetsSentPerSec"` // Packets sent per second
+	PacketsRecvPerSec float64 `json:"packetsRecvPerSec"` // Packets received per second
+	ErrorsInPerSec    float64 `json:"errorsInPerSec"`    // Receive errors per second
+	ErrorsOutPerSec   float64 `json:"errorsOutPerSec"`   // Send errors per second
+	DropsInPerSec     float64 `json:"dropsInPerSec"`     // Incoming drops per second
+	DropsOutPerSec    float64 `json:"dropsOutPerSec"`    // Outgoing drops per second
+	Interval          float64 `json:"interval"`          // Sample interval used for the rates in seconds (0 on first sample)
+}
+
+// Process represents resource usage of a single running process
+type Process struct {
+	PID        int32   `json:"pid"`        // Process identifier
+	Name       string  `json:"name"`       // Process name
+	Cmdline    string  `json:"cmdline"`    // Full command line
+	User       string  `json:"user"`       // Owning user name
+	CPUPercent float64 `json:"cpuPercent"` // CPU usage percentage (100% = one full core)
+	RSS        uint64  `json:"rss"`        // Resident set size in bytes
+	Threads    int32   `json:"threads"`    // Number of threads
+	OpenFDs    int32   `json:"openFds"`    // Number of open file descriptors
+}
+
+// Collector failure reasons reported in CollectorError
+const (
+	CollectorFailed   = "error"   // The collector returned an error
+	CollectorTimedOut = "timeout" // The collector did not finish before its deadline
+	CollectorPanicked = "panic"   // The collector panicked
+)
+
+// CollectorError describes why a collector is missing from a sample
+type CollectorError struct {
+	Collector  string  `json:"collector"`        // Collector name
+	Target     string  `json:"target,omitempty"` // Part of the collector that failed (e.g. a mountpoint), empty when all of it did
+	Reason     string  `json:"reason"`           // One of "error", "timeout" or "panic"
+	Message    string  `json:"message"`          // Human readable cause
+	DurationMs float64 `json:"durationMs"`       // Time spent waiting for the collector in milliseconds
+}
+
+// PartialError is returned by a collector along with the part of its data it did gather
+// The data is kept and each failure is reported in Monitor.Errors
+type PartialError struct {
+	Errors []*CollectorError
+}
+
+func (e *PartialError) Error() string {
+	parts := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		parts[i] = err.Target + ": " + err.Message
+	}
+	return strings.Join(parts, "; ")
 }
 
 // Monitor represents comprehensive system monitoring data
 // Contains all system statistics in a consolidated structure
 type Monitor struct {
-	Host    *Host      `json:"host"`    // Host information
-	Memory  *Memory    `json:"memory"`  // Memory statistics
-	CPU     []*CPU     `json:"cpu"`     // CPU information (one per core)
-	Disk    []*Disk    `json:"disk"`    // Disk information (one per partition)
-	Network []*Network `json:"network"` // Network statistics (one per interface)
+	Host      *Host      `json:"host"`      // Host information
+	Memory    *Memory    `json:"memory"`    // Memory statistics
+	CPU       []*CPU     `json:"cpu"`       // CPU information (one per core)
+	Load      *Load      `json:"load"`      // Load averages and scheduler activity
+	Disk      []*Disk    `json:"disk"`      // Disk information (one per partition)
+	DiskIO    []*DiskIO  `json:"diskIO"`    // Disk I/O statistics (one per block device)
+	Network   []*Network `json:"network"`   // Network statistics (one per interface)
+	Processes []*Process `json:"processes"` // Top processes by CPU usage
+
+	// Errors lists the collectors, or parts of them, that failed or timed out for this sample
+	Errors []*CollectorError `json:"errors"`
+
+	// Custom holds data from registered non built-in collectors, keyed by collector name
+	// Each entry is emitted as its own top-level key in the JSON payload
+	Custom map[string]interface{} `json:"-"`
+}
+
+// monitorPayload is Monitor without its JSON methods, used to avoid recursion
+type monitorPayload Monitor
+
+// MarshalJSON emits the built-in fields followed by one key per custom collector
+func (m Monitor) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(monitorPayload(m))
+	if err != nil || len(m.Custom) == 0 {
+		return data, err
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	for name, value := range m.Custom {
+		if _, taken := fields[name]; taken {
+			continue
+		}
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("collector %s: %w", name, err)
+		}
+		fields[name] = raw
+	}
+
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON restores the built-in fields and keeps any other key as raw custom data
+func (m *Monitor) UnmarshalJSON(data []byte) error {
+	var payload monitorPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	known, err := json.Marshal(monitorPayload{})
+	if err != nil {
+		return err
+	}
+	knownFields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(known, &knownFields); err != nil {
+		return err
+	}
+
+	*m = Monitor(payload)
+	for name, raw := range fields {
+		if _, builtin := knownFields[name]; builtin {
+			continue
+		}
+		if m.Custom == nil {
+			m.Custom = make(map[string]interface{})
+		}
+		m.Custom[name] = raw
+	}
+
+	return nil
+}
+
+// Set stores the result of a collector under its name
+// Built-in names must carry the matching type; any other name is stored in Custom
+func (m *Monitor) Set(name string, value interface{}) error {
+	var ok bool
+	switch name {
+	case CollectorHost:
+		m.Host, ok = value.(*Host)
+	case CollectorMemory:
+		m.Memory, ok = value.(*Memory)
+	case CollectorCPU:
+		m.CPU, ok = value.([]*CPU)
+	case CollectorLoad:
+		m.Load, ok = value.(*Load)
+	case CollectorDisk:
+		m.Disk, ok = value.([]*Disk)
+	case CollectorDiskIO:
+		m.DiskIO, ok = value.([]*DiskIO)
+	case CollectorNetwork:
+		m.Network, ok = value.([]*Network)
+	case CollectorProcesses:
+		m.Processes, ok = value.([]*Process)
+	default:
+		if m.Custom == nil {
+			m.Custom = make(map[string]interface{})
+		}
+		m.Custom[name] = value
+		ok = true
+	}
+
+	if !ok {
+		return fmt.Errorf("collector %s returned unexpected type %T", name, value)
+	}
+	return nil
 }
 
 // String methods for pretty printing
@@ -67,8 +305,13 @@ func (m *Memory) String() string {
 }
 
 func (c *CPU) String() string {
-	return fmt.Sprintf("Model: %s\nCores: %d\nUsage: %.2f%%",
-		c.Model, c.Cores, c.Usage)
+	return fmt.Sprintf("Model: %s\nCores: %d\nUsage: %.2f%%\nUser: %.2f%%\nSystem: %.2f%%\nIowait: %.2f%%\nSteal: %.2f%%",
+		c.Model, c.Cores, c.Usage, c.User, c.System, c.Iowait, c.Steal)
+}
+
+func (l *Load) String() string {
+	return fmt.Sprintf("Load: %.2f %.2f %.2f\nContext Switches: %.2f/s\nInterrupts: %.2f/s",
+		l.Load1, l.Load5, l.Load15, l.ContextSwitchesPerSec, l.InterruptsPerSec)
 }
 
 func (d *Disk) String() string {
@@ -76,13 +319,45 @@ func (d *Disk) String() string {
 		d.Mountpoint, d.Type, d.Total/1024/1024/1024, d.Used/1024/1024/1024, d.Free/1024/1024/1024, d.UsedPercent)
 }
 
+func (d *DiskIO) String() string {
+	return fmt.Sprintf("Device: %s\nMountpoints: %s\nRead: %.2f B/s\nWrite: %.2f B/s\nRead IOPS: %.2f\nWrite IOPS: %.2f\nAwait: %.2f ms\nUtilization: %.2f%%",
+		d.Device, strings.Join(d.Mountpoints, ", "), d.ReadBytesPerSec, d.WriteBytesPerSec, d.ReadIOPS, d.WriteIOPS, d.AwaitMs, d.UtilPercent)
+}
+
 func (n *Network) String() string {
-	return fmt.Sprintf("Total Bytes Sent: %d\nTotal Bytes Received: %d\nInterface Name: %s",
-		n.TotalBytesSent, n.TotalBytesRecv, n.InterfaceName)
+	return fmt.Sprintf("Total Bytes Sent: %d\nTotal Bytes Received: %d\nSent: %.2f B/s\nReceived: %.2f B/s\nInterface Name: %s",
+		n.TotalBytesSent, n.TotalBytesRecv, n.BytesSentPerSec, n.BytesRecvPerSec, n.InterfaceName)
+}
+
+func (p *Process) String() string {
+	return fmt.Sprintf("PID: %d\nName: %s\nUser: %s\nCPU: %.2f%%\nRSS: %.2f MB\nThreads: %d\nOpen FDs: %d",
+		p.PID, p.Name, p.User, p.CPUPercent, float64(p.RSS)/1024/1024, p.Threads, p.OpenFDs)
 }
 
+// String prints the first entry of each section, skipping sections a failed collector left empty
 func (m *Monitor) String() string {
-	return fmt.Sprintf("Host: %s\nOS: %s\nUptime: %d\nMemory: %s\nCPU: %s\nDisk: %s\nNetwork: %s",
-		m.Host.Hostname, m.Host.OS, m.Host.UpTime,
-		m.Memory.String(), m.CPU[0].String(), m.Disk[0].String(), m.Network[0].String())
+	var sections []string
+	if m.Host != nil {
+		sections = append(sections, fmt.Sprintf("Host: %s\nOS: %s\nUptime: %d", m.Host.Hostname, m.Host.OS, m.Host.UpTime))
+	}
+	if m.Memory != nil {
+		sections = append(sections, "Memory: "+m.Memory.String())
+	}
+	if len(m.CPU) > 0 {
+		sections = append(sections, "CPU: "+m.CPU[0].String())
+	}
+	if len(m.Disk) > 0 {
+		sections = append(sections, "Disk: "+m.Disk[0].String())
+	}
+	if len(m.Network) > 0 {
+		sections = append(sections, "Network: "+m.Network[0].String())
+	}
+	for _, err := range m.Errors {
+		source := err.Collector
+		if err.Target != "" {
+			source += " " + err.Target
+		}
+		sections = append(sections, fmt.Sprintf("Error: %s %s: %s", source, err.Reason, err.Message))
+	}
+	return strings.Join(sections, "\n")
 }
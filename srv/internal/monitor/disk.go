@@ -1,20 +1,30 @@
 package monitor
 
 import (
+	"context"
+	"sync"
+	"time"
+
 	"github.com/LissaiDev/Delphos/pkg/logger"
 	"github.com/shirou/gopsutil/v4/disk"
 )
 
+// GetDiskInfo returns space usage for each mounted partition
 func GetDiskInfo() ([]*Disk, error) {
+	return GetDiskInfoWithContext(context.Background())
+}
+
+// GetDiskInfoWithContext returns space usage for each mounted partition, honouring ctx cancellation
+// Partitions that fail or do not answer in time are left out and reported in a
+// *PartialError returned along with the others
+func GetDiskInfoWithContext(ctx context.Context) ([]*Disk, error) {
 	log := logger.GetInstance()
 
 	log.Debug("Starting disk information collection")
 
-	var disks []*Disk
-
 	// Get disk partitions
 	log.Debug("Collecting disk partition information")
-	parts, err := disk.Partitions(false)
+	parts, err := disk.PartitionsWithContext(ctx, false)
 	if err != nil {
 		log.Error("Failed to collect disk partition information", map[string]interface{}{
 			"error": err.Error(),
@@ -33,23 +43,23 @@ func GetDiskInfo() ([]*Disk, error) {
 		}(),
 	})
 
-	// Get usage information for each partition
-	for i, part := range parts {
-		log.Debug("Collecting disk usage for partition", map[string]interface{}{
-			"partition_index": i,
-			"mountpoint":      part.Mountpoint,
-			"filesystem_type": part.Fstype,
-		})
+	// Query every partition at once, so a hung mount only costs its own entry
+	usages := queryDiskUsage(ctx, parts)
 
-		usage, err := disk.Usage(part.Mountpoint)
-		if err != nil {
+	disks := make([]*Disk, 0, len(parts))
+	var failures []*CollectorError
+	for i, part := range parts {
+		if usages[i].err != nil {
 			log.Error("Failed to collect disk usage for partition", map[string]interface{}{
 				"partition_index": i,
 				"mountpoint":      part.Mountpoint,
-				"error":           err.Error(),
+				"reason":          usages[i].err.Reason,
+				"error":           usages[i].err.Message,
 			})
-			return nil, err
+			failures = append(failures, usages[i].err)
+			continue
 		}
+		usage := usages[i].usage
 
 		diskInfo := &Disk{
 			Mountpoint:  part.Mountpoint,
@@ -58,6 +68,11 @@ func GetDiskInfo() ([]*Disk, error) {
 			Used:        float64(usage.Used),
 			Free:        float64(usage.Free),
 			UsedPercent: float64(usage.UsedPercent),
+
+			InodesTotal:       float64(usage.InodesTotal),
+			InodesUsed:        float64(usage.InodesUsed),
+			InodesFree:        float64(usage.InodesFree),
+			InodesUsedPercent: usage.InodesUsedPercent,
 		}
 
 		log.Debug("Disk usage collected for partition", map[string]interface{}{
@@ -91,5 +106,105 @@ func GetDiskInfo() ([]*Disk, error) {
 		}(),
 	})
 
+	if len(failures) > 0 {
+		return disks, &PartialError{Errors: failures}
+	}
 	return disks, nil
 }
+
+// diskMountShare is the part of the collector deadline partitions get to answer
+// The rest leaves time to report the ones that did not before the collector
+// itself is given up on
+const diskMountShare = 0.8
+
+// pendingMounts holds the mountpoints whose usage query has not returned yet
+// statfs cannot be cancelled, so a hung mount (e.g. an unreachable NFS server)
+// is not queried again until its earlier query returns
+var (
+	pendingMounts   = make(map[string]bool)
+	pendingMountsMu sync.Mutex
+)
+
+// diskUsageResult is the outcome of the usage query of one partition
+type diskUsageResult struct {
+	usage *disk.UsageStat
+	err   *CollectorError
+}
+
+// queryDiskUsage queries the usage of every partition concurrently and returns
+// the results in partition order
+// Partitions that do not answer within their share of the ctx deadline are
+// reported as timed out and left running in the background.
+func queryDiskUsage(ctx context.Context, parts []disk.PartitionStat) []diskUsageResult {
+	startTime := time.Now()
+	results := make([]diskUsageResult, len(parts))
+
+	type answer struct {
+		index int
+		usage *disk.UsageStat
+		err   error
+	}
+	answers := make(chan answer, len(parts))
+
+	waiting := make(map[int]bool, len(parts))
+	for i, part := range parts {
+		pendingMountsMu.Lock()
+		stuck := pendingMounts[part.Mountpoint]
+		pendingMounts[part.Mountpoint] = true
+		pendingMountsMu.Unlock()
+
+		if stuck {
+			results[i].err = mountError(part.Mountpoint, CollectorTimedOut, "previous usage query has not finished", startTime)
+			continue
+		}
+
+		waiting[i] = true
+		go func() {
+			usage, err := disk.UsageWithContext(ctx, part.Mountpoint)
+
+			pendingMountsMu.Lock()
+			delete(pendingMounts, part.Mountpoint)
+			pendingMountsMu.Unlock()
+
+			answers <- answer{index: i, usage: usage, err: err}
+		}()
+	}
+
+	var expired <-chan time.Time
+	if deadline, ok := ctx.Deadline(); ok {
+		timer := time.NewTimer(time.Duration(float64(time.Until(deadline)) * diskMountShare))
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+wait:
+	for len(waiting) > 0 {
+		select {
+		case a := <-answers:
+			delete(waiting, a.index)
+			if a.err != nil {
+				results[a.index].err = mountError(parts[a.index].Mountpoint, CollectorFailed, a.err.Error(), startTime)
+				continue
+			}
+			results[a.index].usage = a.usage
+		case <-expired:
+			break wait
+		}
+	}
+
+	for i := range waiting {
+		results[i].err = mountError(parts[i].Mountpoint, CollectorTimedOut, "usage query did not finish in time", startTime)
+	}
+	return results
+}
+
+// mountError describes a partition missing from the disk collection
+func mountError(mountpoint, reason, message string, startTime time.Time) *CollectorError {
+	return &CollectorError{
+		Collector:  CollectorDisk,
+		Target:     mountpoint,
+		Reason:     reason,
+		Message:    message,
+		DurationMs: float64(time.Since(startTime).Microseconds()) / 1000,
+	}
+}
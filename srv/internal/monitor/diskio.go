@@ -0,0 +1,182 @@
+package monitor
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/LissaiDev/Delphos/pkg/logger"
+	"github.com/shirou/gopsutil/v4/disk"
+)
+
+// GetDiskIOInfo returns block device I/O counters mapped to the mountpoints reported by GetDiskInfo
+func GetDiskIOInfo() ([]*DiskIO, error) {
+	return GetDiskIOInfoWithContext(context.Background())
+}
+
+// GetDiskIOInfoWithContext returns block device I/O counters, honouring ctx cancellation
+func GetDiskIOInfoWithContext(ctx context.Context) ([]*DiskIO, error) {
+	log := logger.GetInstance()
+
+	log.Debug("Starting disk I/O information collection")
+
+	// Get block device I/O counters
+	log.Debug("Collecting disk I/O counters")
+	counters, err := disk.IOCountersWithContext(ctx)
+	if err != nil {
+		log.Error("Failed to collect disk I/O counters", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+
+	// Map device names back to mountpoints
+	log.Debug("Collecting disk partition information for device mapping")
+	parts, err := disk.PartitionsWithContext(ctx, false)
+	if err != nil {
+		log.Error("Failed to collect disk partition information", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+
+	mountpoints := make(map[string][]string)
+	for _, part := range parts {
+		device := filepath.Base(part.Device)
+		mountpoints[device] = append(mountpoints[device], part.Mountpoint)
+	}
+
+	log.Debug("Disk I/O devices found", map[string]interface{}{
+		"device_count":    len(counters),
+		"partition_count": len(parts),
+	})
+
+	names := make([]string, 0, len(counters))
+	for name := range counters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var devices []*DiskIO
+	for _, name := range names {
+		counter := counters[name]
+
+		// Device-mapper volumes are mounted by label (/dev/mapper/<label>)
+		mounted := mountpoints[name]
+		if counter.Label != "" && counter.Label != name {
+			mounted = append(mounted, mountpoints[counter.Label]...)
+		}
+
+		device := &DiskIO{
+			Device:           name,
+			Mountpoints:      mounted,
+			TotalReadBytes:   counter.ReadBytes,
+			TotalWriteBytes:  counter.WriteBytes,
+			TotalReadCount:   counter.ReadCount,
+			TotalWriteCount:  counter.WriteCount,
+			TotalReadTimeMs:  counter.ReadTime,
+			TotalWriteTimeMs: counter.WriteTime,
+			TotalIOTimeMs:    counter.IoTime,
+		}
+
+		log.Debug("Disk I/O counters collected for device", map[string]interface{}{
+			"device":      device.Device,
+			"mountpoints": device.Mountpoints,
+			"read_bytes":  device.TotalReadBytes,
+			"write_bytes": device.TotalWriteBytes,
+			"read_count":  device.TotalReadCount,
+			"write_count": device.TotalWriteCount,
+		})
+
+		devices = append(devices, device)
+	}
+
+	log.Debug("Disk I/O information collection completed", map[string]interface{}{
+		"total_devices": len(devices),
+	})
+
+	return devices, nil
+}
+
+// diskIOSample holds the counters observed for a device on the previous collection
+type diskIOSample struct {
+	counters DiskIO
+	at       time.Time
+}
+
+// DiskIORateTracker keeps the previous sample of each block device and
+// derives throughput, IOPS, average await and utilization from counter deltas
+type DiskIORateTracker struct {
+	previous map[string]diskIOSample
+	mu       sync.Mutex
+}
+
+// NewDiskIORateTracker creates an empty disk I/O rate tracker
+func NewDiskIORateTracker() *DiskIORateTracker {
+	return &DiskIORateTracker{
+		previous: make(map[string]diskIOSample),
+	}
+}
+
+// Apply fills in the rate fields of each device using the previous sample.
+// Devices seen for the first time report zero rates and a zero interval.
+func (t *DiskIORateTracker) Apply(devices []*DiskIO, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	seen := make(map[string]bool, len(devices))
+
+	for _, d := range devices {
+		seen[d.Device] = true
+
+		prev, ok := t.previous[d.Device]
+		t.previous[d.Device] = diskIOSample{counters: *d, at: at}
+		if !ok {
+			continue
+		}
+
+		elapsed := at.Sub(prev.at).Seconds()
+		if elapsed <= 0 {
+			continue
+		}
+
+		d.Interval = elapsed
+		d.ReadBytesPerSec = counterRate(prev.counters.TotalReadBytes, d.TotalReadBytes, elapsed)
+		d.WriteBytesPerSec = counterRate(prev.counters.TotalWriteBytes, d.TotalWriteBytes, elapsed)
+		d.ReadIOPS = counterRate(prev.counters.TotalReadCount, d.TotalReadCount, elapsed)
+		d.WriteIOPS = counterRate(prev.counters.TotalWriteCount, d.TotalWriteCount, elapsed)
+
+		// Await is the time spent per completed operation, like iostat's await
+		ops := counterDelta(prev.counters.TotalReadCount, d.TotalReadCount) +
+			counterDelta(prev.counters.TotalWriteCount, d.TotalWriteCount)
+		if ops > 0 {
+			waited := counterDelta(prev.counters.TotalReadTimeMs, d.TotalReadTimeMs) +
+				counterDelta(prev.counters.TotalWriteTimeMs, d.TotalWriteTimeMs)
+			d.AwaitMs = float64(waited) / float64(ops)
+		}
+
+		// Utilization is the share of wall time the device had I/O in flight
+		busy := float64(counterDelta(prev.counters.TotalIOTimeMs, d.TotalIOTimeMs))
+		d.UtilPercent = busy / (elapsed * 1000) * 100
+		if d.UtilPercent > 100 {
+			d.UtilPercent = 100
+		}
+	}
+
+	for name := range t.previous {
+		if !seen[name] {
+			delete(t.previous, name)
+		}
+	}
+}
+
+// counterDelta returns the increase of a monotonic counter, treating a lower
+// current value as a reset
+func counterDelta(previous, current uint64) uint64 {
+	if current < previous {
+		return current
+	}
+	return current - previous
+}
@@ -1,18 +1,25 @@
 package monitor
 
 import (
+	"context"
 	"github.com/LissaiDev/Delphos/pkg/logger"
 	"github.com/shirou/gopsutil/v4/host"
 )
 
+// GetHostInfo returns host information
 func GetHostInfo() (*Host, error) {
+	return GetHostInfoWithContext(context.Background())
+}
+
+// GetHostInfoWithContext returns host information, honouring ctx cancellation
+func GetHostInfoWithContext(ctx context.Context) (*Host, error) {
 	log := logger.GetInstance()
 
 	log.Debug("Starting host information collection")
 
 	// Get host information
 	log.Debug("Collecting host system information")
-	info, err := host.Info()
+	info, err := host.InfoWithContext(ctx)
 	if err != nil {
 		log.Error("Failed to collect host information", map[string]interface{}{
 			"error": err.Error(),
@@ -0,0 +1,46 @@
+//go:build linux
+
+package monitor
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// readTotalInterrupts returns the number of interrupts serviced since boot
+// from the "intr" line of /proc/stat (honouring HOST_PROC like gopsutil)
+func readTotalInterrupts() (uint64, error) {
+	procDir := os.Getenv("HOST_PROC")
+	if procDir == "" {
+		procDir = "/proc"
+	}
+
+	file, err := os.Open(filepath.Join(procDir, "stat"))
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "intr ") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			break
+		}
+		return strconv.ParseUint(fields[1], 10, 64)
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+
+	return 0, errors.New("interrupt counter not found in /proc/stat")
+}
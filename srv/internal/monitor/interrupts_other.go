@@ -0,0 +1,10 @@
+//go:build !linux
+
+package monitor
+
+import "errors"
+
+// readTotalInterrupts is only implemented on Linux
+func readTotalInterrupts() (uint64, error) {
+	return 0, errors.New("interrupt counter not supported on this platform")
+}
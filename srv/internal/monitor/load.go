@@ -0,0 +1,112 @@
+package monitor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/LissaiDev/Delphos/pkg/logger"
+	"github.com/shirou/gopsutil/v4/load"
+)
+
+// GetLoadInfo returns load averages and system-wide scheduler counters
+func GetLoadInfo() (*Load, error) {
+	return GetLoadInfoWithContext(context.Background())
+}
+
+// GetLoadInfoWithContext returns load averages and scheduler counters, honouring ctx cancellation
+func GetLoadInfoWithContext(ctx context.Context) (*Load, error) {
+	log := logger.GetInstance()
+
+	log.Debug("Starting load information collection")
+
+	// Get load averages
+	log.Debug("Collecting load averages")
+	avg, err := load.AvgWithContext(ctx)
+	if err != nil {
+		log.Error("Failed to collect load averages", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+
+	loadInfo := &Load{
+		Load1:  avg.Load1,
+		Load5:  avg.Load5,
+		Load15: avg.Load15,
+	}
+
+	// Scheduler counters are not available on every platform, so they are optional
+	log.Debug("Collecting scheduler counters")
+	if misc, err := load.MiscWithContext(ctx); err == nil {
+		loadInfo.TotalContextSwitches = uint64(misc.Ctxt)
+		loadInfo.ProcsRunning = misc.ProcsRunning
+		loadInfo.ProcsBlocked = misc.ProcsBlocked
+	} else {
+		log.Debug("Scheduler counters unavailable", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	if interrupts, err := readTotalInterrupts(); err == nil {
+		loadInfo.TotalInterrupts = interrupts
+	} else {
+		log.Debug("Interrupt counter unavailable", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	log.Debug("Load information collection completed", map[string]interface{}{
+		"load1":            loadInfo.Load1,
+		"load5":            loadInfo.Load5,
+		"load15":           loadInfo.Load15,
+		"context_switches": loadInfo.TotalContextSwitches,
+		"interrupts":       loadInfo.TotalInterrupts,
+		"procs_running":    loadInfo.ProcsRunning,
+		"procs_blocked":    loadInfo.ProcsBlocked,
+	})
+
+	return loadInfo, nil
+}
+
+// loadSample holds the scheduler counters observed on the previous collection
+type loadSample struct {
+	counters Load
+	at       time.Time
+}
+
+// LoadRateTracker keeps the previous scheduler counters and turns them into per-second rates
+type LoadRateTracker struct {
+	previous *loadSample
+	mu       sync.Mutex
+}
+
+// NewLoadRateTracker creates an empty load rate tracker
+func NewLoadRateTracker() *LoadRateTracker {
+	return &LoadRateTracker{}
+}
+
+// Apply fills in the context switch and interrupt rates using the previous sample
+func (t *LoadRateTracker) Apply(l *Load, at time.Time) {
+	if l == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	prev := t.previous
+	t.previous = &loadSample{counters: *l, at: at}
+	if prev == nil {
+		return
+	}
+
+	elapsed := at.Sub(prev.at).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+
+	l.Interval = elapsed
+	l.ContextSwitchesPerSec = counterRate(prev.counters.TotalContextSwitches, l.TotalContextSwitches, elapsed)
+	l.InterruptsPerSec = counterRate(prev.counters.TotalInterrupts, l.TotalInterrupts, elapsed)
+}
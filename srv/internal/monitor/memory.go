@@ -1,18 +1,25 @@
 package monitor
 
 import (
+	"context"
 	"github.com/LissaiDev/Delphos/pkg/logger"
 	"github.com/shirou/gopsutil/v4/mem"
 )
 
+// GetMemoryInfo returns physical and swap memory statistics
 func GetMemoryInfo() (*Memory, error) {
+	return GetMemoryInfoWithContext(context.Background())
+}
+
+// GetMemoryInfoWithContext returns memory statistics, honouring ctx cancellation
+func GetMemoryInfoWithContext(ctx context.Context) (*Memory, error) {
 	log := logger.GetInstance()
 
 	log.Debug("Starting memory information collection")
 
 	// Get virtual memory information
 	log.Debug("Collecting virtual memory information")
-	info, err := mem.VirtualMemory()
+	info, err := mem.VirtualMemoryWithContext(ctx)
 	if err != nil {
 		log.Error("Failed to collect virtual memory information", map[string]interface{}{
 			"error": err.Error(),
@@ -29,7 +36,7 @@ func GetMemoryInfo() (*Memory, error) {
 
 	// Get swap memory information
 	log.Debug("Collecting swap memory information")
-	swapInfo, swapErr := mem.SwapMemory()
+	swapInfo, swapErr := mem.SwapMemoryWithContext(ctx)
 	if swapErr != nil {
 		log.Error("Failed to collect swap memory information", map[string]interface{}{
 			"error": swapErr.Error(),
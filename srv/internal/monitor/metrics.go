@@ -0,0 +1,280 @@
+package monitor
+
+import (
+	"sort"
+	"strings"
+)
+
+// Metric types, following the Prometheus data model
+const (
+	MetricGauge   = "gauge"   // Value that can go up and down
+	MetricCounter = "counter" // Cumulative value that only resets on restart
+)
+
+// Sample is a single numeric measurement extracted from a Monitor
+// Name is a dotted metric name (e.g. "cpu.usage") and Labels identify the
+// series within the metric (e.g. {"cpu": "cpu0"})
+type Sample struct {
+	Name   string
+	Type   string // MetricGauge or MetricCounter
+	Labels map[string]string
+	Value  float64
+}
+
+// MetricDesc documents a metric emitted by Monitor.Samples
+type MetricDesc struct {
+	Name string
+	Type string
+	Help string
+}
+
+// metricDescs lists every built-in metric
+var metricDescs = map[string]MetricDesc{}
+
+func init() {
+	for _, desc := range []MetricDesc{
+		{"host.uptime", MetricGauge, "System uptime in seconds."},
+
+		{"memory.total", MetricGauge, "Total physical memory in bytes."},
+		{"memory.used", MetricGauge, "Used physical memory in bytes."},
+		{"memory.free", MetricGauge, "Free physical memory in bytes."},
+		{"memory.used_percent", MetricGauge, "Used physical memory in percent."},
+		{"memory.swap_total", MetricGauge, "Total swap in bytes."},
+		{"memory.swap_used", MetricGauge, "Used swap in bytes."},
+		{"memory.swap_free", MetricGauge, "Free swap in bytes."},
+		{"memory.swap_used_percent", MetricGauge, "Used swap in percent."},
+
+		{"cpu.usage", MetricGauge, "CPU usage in percent over the collection interval."},
+		{"cpu.user", MetricGauge, "CPU time spent in user mode in percent."},
+		{"cpu.system", MetricGauge, "CPU time spent in kernel mode in percent."},
+		{"cpu.nice", MetricGauge, "CPU time spent on niced processes in percent."},
+		{"cpu.idle", MetricGauge, "CPU idle time in percent."},
+		{"cpu.iowait", MetricGauge, "CPU time spent waiting for I/O in percent."},
+		{"cpu.irq", MetricGauge, "CPU time spent servicing hardware interrupts in percent."},
+		{"cpu.softirq", MetricGauge, "CPU time spent servicing software interrupts in percent."},
+		{"cpu.steal", MetricGauge, "CPU time stolen by the hypervisor in percent."},
+		{"cpu.usage_avg", MetricGauge, "CPU usage in percent averaged across all cores."},
+		{"cpu.seconds", MetricCounter, "Seconds the CPU spent in each mode since boot."},
+
+		{"load.load1", MetricGauge, "1 minute load average."},
+		{"load.load5", MetricGauge, "5 minute load average."},
+		{"load.load15", MetricGauge, "15 minute load average."},
+		{"load.procs_running", MetricGauge, "Processes currently runnable."},
+		{"load.procs_blocked", MetricGauge, "Processes blocked on I/O."},
+		{"load.context_switches_per_sec", MetricGauge, "Context switches per second."},
+		{"load.interrupts_per_sec", MetricGauge, "Interrupts serviced per second."},
+		{"load.context_switches", MetricCounter, "Context switches since boot."},
+		{"load.interrupts", MetricCounter, "Interrupts serviced since boot."},
+
+		{"disk.total", MetricGauge, "Total size of the filesystem in bytes."},
+		{"disk.used", MetricGauge, "Used space on the filesystem in bytes."},
+		{"disk.free", MetricGauge, "Free space on the filesystem in bytes."},
+		{"disk.used_percent", MetricGauge, "Used space on the filesystem in percent."},
+		{"disk.inodes_total", MetricGauge, "Total inodes of the filesystem."},
+		{"disk.inodes_used", MetricGauge, "Used inodes of the filesystem."},
+		{"disk.inodes_free", MetricGauge, "Free inodes of the filesystem."},
+		{"disk.inodes_used_percent", MetricGauge, "Used inodes of the filesystem in percent."},
+
+		{"diskio.read_bytes_per_sec", MetricGauge, "Bytes read from the device per second."},
+		{"diskio.write_bytes_per_sec", MetricGauge, "Bytes written to the device per second."},
+		{"diskio.read_iops", MetricGauge, "Reads completed per second."},
+		{"diskio.write_iops", MetricGauge, "Writes completed per second."},
+		{"diskio.await_ms", MetricGauge, "Average time per I/O operation in milliseconds."},
+		{"diskio.util_percent", MetricGauge, "Share of time the device was busy in percent."},
+		{"diskio.read_bytes", MetricCounter, "Bytes read from the device."},
+		{"diskio.write_bytes", MetricCounter, "Bytes written to the device."},
+		{"diskio.reads", MetricCounter, "Reads completed by the device."},
+		{"diskio.writes", MetricCounter, "Writes completed by the device."},
+		{"diskio.io_time_seconds", MetricCounter, "Seconds the device had I/O in flight."},
+
+		{"network.bytes_sent_per_sec", MetricGauge, "Bytes sent per second."},
+		{"network.bytes_recv_per_sec", MetricGauge, "Bytes received per second."},
+		{"network.packets_sent_per_sec", MetricGauge, "Packets sent per second."},
+		{"network.packets_recv_per_sec", MetricGauge, "Packets received per second."},
+		{"network.errors_in_per_sec", MetricGauge, "Receive errors per second."},
+		{"network.errors_out_per_sec", MetricGauge, "Send errors per second."},
+		{"network.drops_in_per_sec", MetricGauge, "Incoming packets dropped per second."},
+		{"network.drops_out_per_sec", MetricGauge, "Outgoing packets dropped per second."},
+		{"network.bytes_sent", MetricCounter, "Bytes sent by the interface."},
+		{"network.bytes_recv", MetricCounter, "Bytes received by the interface."},
+		{"network.packets_sent", MetricCounter, "Packets sent by the interface."},
+		{"network.packets_recv", MetricCounter, "Packets received by the interface."},
+		{"network.errors_in", MetricCounter, "Receive errors on the interface."},
+		{"network.errors_out", MetricCounter, "Send errors on the interface."},
+		{"network.drops_in", MetricCounter, "Incoming packets dropped by the interface."},
+		{"network.drops_out", MetricCounter, "Outgoing packets dropped by the interface."},
+	} {
+		metricDescs[desc.Name] = desc
+	}
+}
+
+// DescribeMetric returns the description of a built-in metric
+func DescribeMetric(name string) (MetricDesc, bool) {
+	desc, exists := metricDescs[name]
+	return desc, exists
+}
+
+// Key returns a stable identifier for the series the sample belongs to
+func (s Sample) Key() string {
+	return SeriesKey(s.Name, s.Labels)
+}
+
+// SeriesKey renders a metric name and its labels as name{k=v,...} with sorted keys
+func SeriesKey(name string, labels map[string]string) string {
+	if len(labels) == 0 {
+		return name
+	}
+
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(name)
+	b.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(labels[k])
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// Samples flattens the built-in sections of the Monitor into numeric samples
+// Rates are only emitted once a previous sample exists (Interval > 0), and
+// processes and custom collectors are left out
+func (m *Monitor) Samples() []Sample {
+	var samples []Sample
+	add := func(name string, value float64, labels map[string]string) {
+		samples = append(samples, Sample{Name: name, Type: metricDescs[name].Type, Labels: labels, Value: value})
+	}
+
+	if m.Host != nil {
+		add("host.uptime", float64(m.Host.UpTime), nil)
+	}
+
+	if m.Memory != nil {
+		add("memory.total", m.Memory.Total, nil)
+		add("memory.used", m.Memory.Used, nil)
+		add("memory.free", m.Memory.Free, nil)
+		if m.Memory.Total > 0 {
+			add("memory.used_percent", m.Memory.Used/m.Memory.Total*100, nil)
+		}
+		add("memory.swap_total", m.Memory.SwapTotal, nil)
+		add("memory.swap_used", m.Memory.SwapUsed, nil)
+		add("memory.swap_free", m.Memory.SwapFree, nil)
+		if m.Memory.SwapTotal > 0 {
+			add("memory.swap_used_percent", m.Memory.SwapUsed/m.Memory.SwapTotal*100, nil)
+		}
+	}
+
+	if len(m.CPU) > 0 {
+		sum := 0.0
+		for _, c := range m.CPU {
+			labels := map[string]string{"cpu": c.Name}
+			add("cpu.usage", c.Usage, labels)
+			add("cpu.user", c.User, labels)
+			add("cpu.system", c.System, labels)
+			add("cpu.nice", c.Nice, labels)
+			add("cpu.idle", c.Idle, labels)
+			add("cpu.iowait", c.Iowait, labels)
+			add("cpu.irq", c.Irq, labels)
+			add("cpu.softirq", c.Softirq, labels)
+			add("cpu.steal", c.Steal, labels)
+			sum += c.Usage
+
+			if c.Times != nil {
+				for _, mode := range []struct {
+					name    string
+					seconds float64
+				}{
+					{"user", c.Times.User}, {"system", c.Times.System}, {"nice", c.Times.Nice}, {"idle", c.Times.Idle},
+					{"iowait", c.Times.Iowait}, {"irq", c.Times.Irq}, {"softirq", c.Times.Softirq}, {"steal", c.Times.Steal},
+				} {
+					add("cpu.seconds", mode.seconds, map[string]string{"cpu": c.Name, "mode": mode.name})
+				}
+			}
+		}
+		add("cpu.usage_avg", sum/float64(len(m.CPU)), nil)
+	}
+
+	if m.Load != nil {
+		add("load.load1", m.Load.Load1, nil)
+		add("load.load5", m.Load.Load5, nil)
+		add("load.load15", m.Load.Load15, nil)
+		add("load.procs_running", float64(m.Load.ProcsRunning), nil)
+		add("load.procs_blocked", float64(m.Load.ProcsBlocked), nil)
+		if m.Load.Interval > 0 {
+			add("load.context_switches_per_sec", m.Load.ContextSwitchesPerSec, nil)
+			add("load.interrupts_per_sec", m.Load.InterruptsPerSec, nil)
+		}
+		add("load.context_switches", float64(m.Load.TotalContextSwitches), nil)
+		if m.Load.TotalInterrupts > 0 {
+			add("load.interrupts", float64(m.Load.TotalInterrupts), nil)
+		}
+	}
+
+	for _, d := range m.Disk {
+		labels := map[string]string{"mountpoint": d.Mountpoint, "fstype": d.Type}
+		add("disk.total", d.Total, labels)
+		add("disk.used", d.Used, labels)
+		add("disk.free", d.Free, labels)
+		add("disk.used_percent", d.UsedPercent, labels)
+		// Some file systems (e.g. btrfs, vfat) have no fixed inode table
+		if d.InodesTotal > 0 {
+			add("disk.inodes_total", d.InodesTotal, labels)
+			add("disk.inodes_used", d.InodesUsed, labels)
+			add("disk.inodes_free", d.InodesFree, labels)
+			add("disk.inodes_used_percent", d.InodesUsedPercent, labels)
+		}
+	}
+
+	for _, d := range m.DiskIO {
+		labels := map[string]string{"device": d.Device}
+		add("diskio.read_bytes", float64(d.TotalReadBytes), labels)
+		add("diskio.write_bytes", float64(d.TotalWriteBytes), labels)
+		add("diskio.reads", float64(d.TotalReadCount), labels)
+		add("diskio.writes", float64(d.TotalWriteCount), labels)
+		add("diskio.io_time_seconds", float64(d.TotalIOTimeMs)/1000, labels)
+		if d.Interval <= 0 {
+			continue
+		}
+		add("diskio.read_bytes_per_sec", d.ReadBytesPerSec, labels)
+		add("diskio.write_bytes_per_sec", d.WriteBytesPerSec, labels)
+		add("diskio.read_iops", d.ReadIOPS, labels)
+		add("diskio.write_iops", d.WriteIOPS, labels)
+		add("diskio.await_ms", d.AwaitMs, labels)
+		add("diskio.util_percent", d.UtilPercent, labels)
+	}
+
+	for _, n := range m.Network {
+		labels := map[string]string{"interface": n.InterfaceName}
+		add("network.bytes_sent", float64(n.TotalBytesSent), labels)
+		add("network.bytes_recv", float64(n.TotalBytesRecv), labels)
+		add("network.packets_sent", float64(n.TotalPacketsSent), labels)
+		add("network.packets_recv", float64(n.TotalPacketsRecv), labels)
+		add("network.errors_in", float64(n.TotalErrorsIn), labels)
+		add("network.errors_out", float64(n.TotalErrorsOut), labels)
+		add("network.drops_in", float64(n.TotalDropsIn), labels)
+		add("network.drops_out", float64(n.TotalDropsOut), labels)
+		if n.Interval <= 0 {
+			continue
+		}
+		add("network.bytes_sent_per_sec", n.BytesSentPerSec, labels)
+		add("network.bytes_recv_per_sec", n.BytesRecvPerSec, labels)
+		add("network.packets_sent_per_sec", n.PacketsSentPerSec, labels)
+		add("network.packets_recv_per_sec", n.PacketsRecvPerSec, labels)
+		add("network.errors_in_per_sec", n.ErrorsInPerSec, labels)
+		add("network.errors_out_per_sec", n.ErrorsOutPerSec, labels)
+		add("network.drops_in_per_sec", n.DropsInPerSec, labels)
+		add("network.drops_out_per_sec", n.DropsOutPerSec, labels)
+	}
+
+	return samples
+}
@@ -1,116 +1,155 @@
 package monitor
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
 	"github.com/LissaiDev/Delphos/internal/config"
-	"github.com/LissaiDev/Delphos/pkg/echo"
 	"github.com/LissaiDev/Delphos/pkg/logger"
 )
 
 // StatsService handles system statistics collection and management
 type StatsService struct {
-	logger   logger.BasicLogger
-	notifier echo.Notifier
+	logger    logger.BasicLogger
+	registry  *Registry
+	timeout   time.Duration
+	abandoned map[string]*collectorRun // Timed out runs that have not returned yet
+	runsMu    sync.Mutex
+	collectMu sync.Mutex // Serializes collections, so rate windows never overlap
+	latest    *Monitor   // Result of the latest collection
+	latestAt  time.Time
+	latestMu  sync.Mutex
 }
 
+// collectorRun tracks a single invocation of a collector
+type collectorRun struct {
+	finished bool
+}
+
+// collectorResult holds the outcome of a single collector run
+type collectorResult struct {
+	data    interface{}
+	err     *CollectorError
+	partial []*CollectorError // Failed parts of a collector that returned data
+}
+
+// ErrNoCollectorData is returned when every enabled collector failed
+var ErrNoCollectorData = errors.New("all collectors failed")
+
 var (
 	StatsServiceInstance *StatsService
 	once                 sync.Once
 )
 
 // New creates a new stats service instance
-func New(log logger.BasicLogger, notifier echo.Notifier) *StatsService {
+// timeout is the default per-collector deadline
+func New(log logger.BasicLogger, registry *Registry, timeout time.Duration) *StatsService {
 	return &StatsService{
-		logger:   log,
-		notifier: notifier,
+		logger:    log,
+		registry:  registry,
+		timeout:   timeout,
+		abandoned: make(map[string]*collectorRun),
 	}
 }
 
+// Registry returns the collector registry used by the service
+func (s *StatsService) Registry() *Registry {
+	return s.registry
+}
+
 // GetStats retrieves comprehensive system statistics
+// Rates are computed against the previous collection, so callers outside the
+// periodic collection should use GetLatestStats instead
 func (s *StatsService) GetStats() (*Monitor, error) {
-	startTime := time.Now()
+	s.collectMu.Lock()
+	defer s.collectMu.Unlock()
 
-	s.logger.Debug("Starting system statistics collection", map[string]interface{}{
-		"timestamp": startTime.Format(time.RFC3339),
-	})
+	return s.collectStats()
+}
 
-	// Collect all system information
-	host, err := s.collectHostInfo()
-	if err != nil {
-		return nil, err
+// GetLatestStats returns the result of the latest collection when it is at most
+// maxAge old, and collects anew otherwise
+// The returned statistics are shared and must not be modified
+func (s *StatsService) GetLatestStats(maxAge time.Duration) (*Monitor, error) {
+	if latest := s.fresh(maxAge); latest != nil {
+		return latest, nil
 	}
 
-	mem, err := s.collectMemoryInfo()
-	if err != nil {
-		return nil, err
-	}
+	s.collectMu.Lock()
+	defer s.collectMu.Unlock()
 
-	cpu, err := s.collectCPUInfo()
-	if err != nil {
-		return nil, err
+	// Another caller may have collected while we waited
+	if latest := s.fresh(maxAge); latest != nil {
+		return latest, nil
 	}
+	return s.collectStats()
+}
 
-	disk, err := s.collectDiskInfo()
-	if err != nil {
-		return nil, err
-	}
+// fresh returns the latest result if it is at most maxAge old
+func (s *StatsService) fresh(maxAge time.Duration) *Monitor {
+	s.latestMu.Lock()
+	defer s.latestMu.Unlock()
 
-	net, err := s.collectNetworkInfo()
-	if err != nil {
-		return nil, err
+	if s.latest == nil || time.Since(s.latestAt) > maxAge {
+		return nil
 	}
+	return s.latest
+}
 
-	// Create consolidated result
-	result := &Monitor{
-		Host:    host,
-		Memory:  mem,
-		CPU:     cpu,
-		Disk:    disk,
-		Network: net,
-	}
+// collectStats runs the collectors and records the result as the latest; callers hold collectMu
+func (s *StatsService) collectStats() (*Monitor, error) {
+	startTime := time.Now()
 
-	// ALERT: Check thresholds and notify if necessary
-	cfg := config.Env
+	s.logger.Debug("Starting system statistics collection", map[string]interface{}{
+		"timestamp": startTime.Format(time.RFC3339),
+	})
 
-	// CPU: average across all cores
-	if len(cpu) > 0 {
-		sum := 0.0
-		for _, c := range cpu {
-			sum += c.Usage
-		}
-		avg := sum / float64(len(cpu))
-		if avg > cfg.CPUThreshold {
-			_ = s.notifier.Notify(
-				fmt.Sprintf("[ALERT]: CPU usage above threshold (%.1f%% > %.1f%%)", avg, cfg.CPUThreshold),
-			)
-		}
-	}
+	// Collect all system information from the enabled collectors concurrently,
+	// keeping whatever succeeded and recording why the rest is missing
+	collectors := s.registry.Collectors()
+	results := s.collectAll(collectors)
 
-	// Memory
-	if mem.Total > 0 {
-		memPercent := (mem.Used / mem.Total) * 100
-		if memPercent > cfg.MemoryThreshold {
-			_ = s.notifier.Notify(
-				fmt.Sprintf("[ALERT]: Memory usage above threshold (%.1f%% > %.1f%%)", memPercent, cfg.MemoryThreshold),
-			)
+	result := &Monitor{}
+	failed := 0
+	for i, collector := range collectors {
+		if results[i].err != nil {
+			result.Errors = append(result.Errors, results[i].err)
+			failed++
+			continue
+		}
+		result.Errors = append(result.Errors, results[i].partial...)
+		if err := result.Set(collector.Name(), results[i].data); err != nil {
+			s.logger.Error("Collector returned invalid data", map[string]interface{}{
+				"collector": collector.Name(),
+				"error":     err.Error(),
+			})
+			result.Errors = append(result.Errors, &CollectorError{
+				Collector: collector.Name(),
+				Reason:    CollectorFailed,
+				Message:   err.Error(),
+			})
+			failed++
 		}
 	}
 
-	// Disk: any partition above the threshold
-	for _, d := range disk {
-		if d.UsedPercent > cfg.DiskThreshold {
-			_ = s.notifier.Notify(
-				fmt.Sprintf("[ALERT]: Disk usage on %s above threshold (%.1f%% > %.1f%%)", d.Mountpoint, d.UsedPercent, cfg.DiskThreshold),
-			)
-		}
+	if len(collectors) > 0 && failed == len(collectors) {
+		s.logger.Error("Every collector failed", map[string]interface{}{
+			"collectors": len(collectors),
+		})
+		return nil, ErrNoCollectorData
 	}
 
 	s.logCompletionStats(result, time.Since(startTime))
 
+	s.latestMu.Lock()
+	s.latest = result
+	s.latestAt = startTime
+	s.latestMu.Unlock()
+
 	return result, nil
 }
 
@@ -123,82 +162,162 @@ func (s *StatsService) GetStatsJSON() ([]byte, error) {
 	return json.Marshal(stats)
 }
 
-// collectWithLogging is a DRY helper for collecting system info with consistent logging
-func (s *StatsService) collectHostInfo() (*Host, error) {
-	s.logger.Debug("Collecting host information")
-	result, err := GetHostInfo()
-	if err != nil {
-		s.logger.Error("Failed to collect host information", map[string]interface{}{
-			"error": err.Error(),
-		})
-		return nil, err
+// collectAll runs every collector concurrently and returns their results in the same order
+func (s *StatsService) collectAll(collectors []Collector) []collectorResult {
+	results := make([]collectorResult, len(collectors))
+
+	var wg sync.WaitGroup
+	for i, collector := range collectors {
+		wg.Add(1)
+		go func(i int, collector Collector) {
+			defer wg.Done()
+			results[i] = s.collect(collector)
+		}(i, collector)
 	}
-	s.logger.Debug("Host information collected successfully")
-	return result, nil
+	wg.Wait()
+
+	return results
 }
 
-func (s *StatsService) collectMemoryInfo() (*Memory, error) {
-	s.logger.Debug("Collecting memory information")
-	result, err := GetMemoryInfo()
-	if err != nil {
-		s.logger.Error("Failed to collect memory information", map[string]interface{}{
-			"error": err.Error(),
-		})
-		return nil, err
+// collect runs a single collector under its deadline with consistent logging
+// A collector that misses its deadline is abandoned rather than waited for, and
+// is not started again until the abandoned run returns
+func (s *StatsService) collect(collector Collector) collectorResult {
+	name := collector.Name()
+	startTime := time.Now()
+
+	timeout := s.timeout
+	if tc, ok := collector.(TimeoutCollector); ok && tc.Timeout() > 0 {
+		timeout = tc.Timeout()
 	}
-	s.logger.Debug("Memory information collected successfully")
-	return result, nil
-}
 
-func (s *StatsService) collectCPUInfo() ([]*CPU, error) {
-	s.logger.Debug("Collecting cpu information")
-	result, err := GetCPUInfo()
-	if err != nil {
-		s.logger.Error("Failed to collect cpu information", map[string]interface{}{
-			"error": err.Error(),
+	s.runsMu.Lock()
+	_, stuck := s.abandoned[name]
+	run := &collectorRun{}
+	s.runsMu.Unlock()
+
+	if stuck {
+		s.logger.Warn("Skipping collector, previous run has not finished", map[string]interface{}{
+			"collector": name,
 		})
-		return nil, err
+		return collectorResult{err: &CollectorError{
+			Collector: name,
+			Reason:    CollectorTimedOut,
+			Message:   "previous collection has not finished",
+		}}
 	}
-	s.logger.Debug("CPU information collected successfully")
-	return result, nil
-}
 
-func (s *StatsService) collectDiskInfo() ([]*Disk, error) {
-	s.logger.Debug("Collecting disk information")
-	result, err := GetDiskInfo()
-	if err != nil {
-		s.logger.Error("Failed to collect disk information", map[string]interface{}{
-			"error": err.Error(),
+	s.logger.Debug("Collecting "+name+" information", map[string]interface{}{
+		"collector": name,
+		"timeout":   timeout.String(),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	done := make(chan collectorResult, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- collectorResult{err: &CollectorError{
+					Collector: name,
+					Reason:    CollectorPanicked,
+					Message:   fmt.Sprint(r),
+				}}
+			}
+
+			s.runsMu.Lock()
+			run.finished = true
+			if s.abandoned[name] == run {
+				delete(s.abandoned, name)
+			}
+			s.runsMu.Unlock()
+		}()
+
+		data, err := collector.Collect(ctx)
+		var partial *PartialError
+		if errors.As(err, &partial) && data != nil {
+			done <- collectorResult{data: data, partial: partial.Errors}
+			return
+		}
+		if err != nil {
+			reason := CollectorFailed
+			if errors.Is(err, context.DeadlineExceeded) {
+				reason = CollectorTimedOut
+			}
+			done <- collectorResult{err: &CollectorError{
+				Collector: name,
+				Reason:    reason,
+				Message:   err.Error(),
+			}}
+			return
+		}
+		done <- collectorResult{data: data}
+	}()
+
+	var result collectorResult
+	select {
+	case result = <-done:
+	case <-ctx.Done():
+		s.runsMu.Lock()
+		if !run.finished {
+			s.abandoned[name] = run
+		}
+		s.runsMu.Unlock()
+
+		result = collectorResult{err: &CollectorError{
+			Collector: name,
+			Reason:    CollectorTimedOut,
+			Message:   "collector did not finish within " + timeout.String(),
+		}}
+	}
+
+	duration := time.Since(startTime)
+	if result.err != nil {
+		result.err.DurationMs = float64(duration.Microseconds()) / 1000
+		s.logger.Error("Failed to collect "+name+" information", map[string]interface{}{
+			"collector": name,
+			"reason":    result.err.Reason,
+			"error":     result.err.Message,
+			"duration":  duration.String(),
 		})
-		return nil, err
+		return result
 	}
-	s.logger.Debug("Disk information collected successfully")
-	return result, nil
-}
 
-func (s *StatsService) collectNetworkInfo() ([]*Network, error) {
-	s.logger.Debug("Collecting network information")
-	result, err := GetNetworkInfo()
-	if err != nil {
-		s.logger.Error("Failed to collect network information", map[string]interface{}{
-			"error": err.Error(),
+	if len(result.partial) > 0 {
+		s.logger.Warn("Collected part of the "+name+" information", map[string]interface{}{
+			"collector": name,
+			"failures":  len(result.partial),
+			"duration":  duration.String(),
 		})
-		return nil, err
+		return result
 	}
-	s.logger.Debug("Network information collected successfully")
-	return result, nil
+
+	s.logger.Debug(name+" information collected successfully", map[string]interface{}{
+		"collector": name,
+		"duration":  duration.String(),
+	})
+	return result
 }
 
 // logCompletionStats logs the completion statistics
 func (s *StatsService) logCompletionStats(result *Monitor, duration time.Duration) {
 	s.logger.Info("System statistics collection completed", map[string]interface{}{
-		"collection_time":    duration.String(),
-		"hostname":           result.Host.Hostname,
+		"collection_time": duration.String(),
+		"hostname": func() string {
+			if result.Host != nil {
+				return result.Host.Hostname
+			}
+			return ""
+		}(),
 		"cpu_cores":          len(result.CPU),
 		"disk_partitions":    len(result.Disk),
+		"disk_devices":       len(result.DiskIO),
 		"network_interfaces": len(result.Network),
+		"processes":          len(result.Processes),
+		"collector_errors":   len(result.Errors),
 		"memory_usage_percent": func() float64 {
-			if result.Memory.Total > 0 {
+			if result.Memory != nil && result.Memory.Total > 0 {
 				return (result.Memory.Used / result.Memory.Total) * 100
 			}
 			return 0
@@ -214,15 +333,28 @@ func (s *StatsService) logCompletionStats(result *Monitor, duration time.Duratio
 
 func GetInstance() *StatsService {
 	log := logger.GetInstance()
-	notifier := echo.GetInstance()
 	once.Do(func() {
-		StatsServiceInstance = New(log, notifier)
+		timeout := time.Duration(config.Env.CollectorTimeout) * time.Millisecond
+		StatsServiceInstance = New(log, NewDefaultRegistry(), timeout)
 	})
 	return StatsServiceInstance
 }
 
+// Register adds a collector to the shared stats service
+// Intended for in-house collectors registered at startup
+func Register(collector Collector) error {
+	return GetInstance().Registry().Register(collector)
+}
+
 // GetSystemStats provides backward compatibility
 func GetSystemStats() (*Monitor, error) {
 	service := GetInstance()
 	return service.GetStats()
 }
+
+// GetLatestSystemStats returns the statistics of the periodic collection
+// It only collects when nothing was collected within the last two intervals,
+// so API requests do not shorten the windows rates are computed over
+func GetLatestSystemStats() (*Monitor, error) {
+	return GetInstance().GetLatestStats(2 * time.Duration(config.Env.Interval) * time.Second)
+}
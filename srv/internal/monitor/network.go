@@ -1,11 +1,21 @@
 package monitor
 
 import (
+	"context"
+	"sync"
+	"time"
+
 	"github.com/LissaiDev/Delphos/pkg/logger"
 	"github.com/shirou/gopsutil/v4/net"
 )
 
+// GetNetworkInfo returns counters for each network interface
 func GetNetworkInfo() ([]*Network, error) {
+	return GetNetworkInfoWithContext(context.Background())
+}
+
+// GetNetworkInfoWithContext returns counters for each network interface, honouring ctx cancellation
+func GetNetworkInfoWithContext(ctx context.Context) ([]*Network, error) {
 	log := logger.GetInstance()
 	log.Debug("Starting network information collection")
 
@@ -13,7 +23,7 @@ func GetNetworkInfo() ([]*Network, error) {
 
 	// Get network I/O counters
 	log.Debug("Collecting network I/O counters")
-	netStats, err := net.IOCounters(true)
+	netStats, err := net.IOCountersWithContext(ctx, true)
 	if err != nil {
 		log.Error("Failed to collect network I/O counters", map[string]interface{}{
 			"error": err.Error(),
@@ -44,9 +54,15 @@ func GetNetworkInfo() ([]*Network, error) {
 		})
 
 		network := &Network{
-			InterfaceName:  netStat.Name,
-			TotalBytesSent: netStat.BytesSent,
-			TotalBytesRecv: netStat.BytesRecv,
+			InterfaceName:    netStat.Name,
+			TotalBytesSent:   netStat.BytesSent,
+			TotalBytesRecv:   netStat.BytesRecv,
+			TotalPacketsSent: netStat.PacketsSent,
+			TotalPacketsRecv: netStat.PacketsRecv,
+			TotalErrorsIn:    netStat.Errin,
+			TotalErrorsOut:   netStat.Errout,
+			TotalDropsIn:     netStat.Dropin,
+			TotalDropsOut:    netStat.Dropout,
 		}
 
 		networks = append(networks, network)
@@ -72,3 +88,71 @@ func GetNetworkInfo() ([]*Network, error) {
 
 	return networks, nil
 }
+
+// networkSample holds the counters observed for an interface on the previous collection
+type networkSample struct {
+	counters Network
+	at       time.Time
+}
+
+// NetworkRateTracker keeps the previous sample of each interface and
+// turns cumulative counters into per-second rates
+type NetworkRateTracker struct {
+	previous map[string]networkSample
+	mu       sync.Mutex
+}
+
+// NewNetworkRateTracker creates an empty network rate tracker
+func NewNetworkRateTracker() *NetworkRateTracker {
+	return &NetworkRateTracker{
+		previous: make(map[string]networkSample),
+	}
+}
+
+// Apply fills in the rate fields of each interface using the previous sample.
+// Interfaces seen for the first time report zero rates and a zero interval;
+// interfaces that disappeared are forgotten so they start fresh if they return.
+func (t *NetworkRateTracker) Apply(networks []*Network, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	seen := make(map[string]bool, len(networks))
+
+	for _, n := range networks {
+		seen[n.InterfaceName] = true
+
+		prev, ok := t.previous[n.InterfaceName]
+		t.previous[n.InterfaceName] = networkSample{counters: *n, at: at}
+		if !ok {
+			continue
+		}
+
+		elapsed := at.Sub(prev.at).Seconds()
+		if elapsed <= 0 {
+			continue
+		}
+
+		n.Interval = elapsed
+		n.BytesSentPerSec = counterRate(prev.counters.TotalBytesSent, n.TotalBytesSent, elapsed)
+		n.BytesRecvPerSec = counterRate(prev.counters.TotalBytesRecv, n.TotalBytesRecv, elapsed)
+		n.PacketsSentPerSec = counterRate(prev.counters.TotalPacketsSent, n.TotalPacketsSent, elapsed)
+		n.PacketsRecvPerSec = counterRate(prev.counters.TotalPacketsRecv, n.TotalPacketsRecv, elapsed)
+		n.ErrorsInPerSec = counterRate(prev.counters.TotalErrorsIn, n.TotalErrorsIn, elapsed)
+		n.ErrorsOutPerSec = counterRate(prev.counters.TotalErrorsOut, n.TotalErrorsOut, elapsed)
+		n.DropsInPerSec = counterRate(prev.counters.TotalDropsIn, n.TotalDropsIn, elapsed)
+		n.DropsOutPerSec = counterRate(prev.counters.TotalDropsOut, n.TotalDropsOut, elapsed)
+	}
+
+	for name := range t.previous {
+		if !seen[name] {
+			delete(t.previous, name)
+		}
+	}
+}
+
+// counterRate returns the per-second increase of a monotonic counter.
+// A counter lower than its previous value is treated as a reset (e.g. the
+// interface was re-created), in which case the current value is the increase.
+func counterRate(previous, current uint64, elapsed float64) float64 {
+	return float64(counterDelta(previous, current)) / elapsed
+}
@@ -0,0 +1,199 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/LissaiDev/Delphos/pkg/logger"
+	"github.com/shirou/gopsutil/v4/process"
+)
+
+// Process sort keys accepted by GetProcessInfo
+const (
+	ProcessSortCPU    = "cpu"
+	ProcessSortMemory = "memory"
+)
+
+// processMinWindow is the minimum time between two samples before the CPU
+// baseline of a process is replaced. Calls made closer together reuse the
+// previous baseline so back-to-back requests still report meaningful usage.
+const processMinWindow = 500 * time.Millisecond
+
+// ErrInvalidProcessSort is returned when an unknown sort key is requested
+var ErrInvalidProcessSort = errors.New("invalid process sort key")
+
+// processSample holds the CPU time observed for a process on a previous collection
+type processSample struct {
+	cpuTotal float64   // User + system CPU time in seconds
+	at       time.Time // When the sample was taken
+}
+
+var (
+	processSamples   = make(map[int32]processSample)
+	processSamplesMu sync.Mutex
+)
+
+// processEntry pairs a gopsutil process handle with its computed metrics
+type processEntry struct {
+	handle *process.Process
+	info   *Process
+}
+
+// GetProcessInfo returns the top processes ordered by the given sort key.
+// A limit of zero or less returns every process.
+func GetProcessInfo(limit int, sortBy string) ([]*Process, error) {
+	return GetProcessInfoWithContext(context.Background(), limit, sortBy)
+}
+
+// GetProcessInfoWithContext returns the top processes, honouring ctx cancellation
+func GetProcessInfoWithContext(ctx context.Context, limit int, sortBy string) ([]*Process, error) {
+	log := logger.GetInstance()
+
+	log.Debug("Starting process information collection", map[string]interface{}{
+		"limit":   limit,
+		"sort_by": sortBy,
+	})
+
+	if sortBy != ProcessSortCPU && sortBy != ProcessSortMemory {
+		log.Error("Invalid process sort key", map[string]interface{}{
+			"sort_by": sortBy,
+		})
+		return nil, ErrInvalidProcessSort
+	}
+
+	// Get running processes
+	log.Debug("Collecting process list")
+	procs, err := process.ProcessesWithContext(ctx)
+	if err != nil {
+		log.Error("Failed to collect process list", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+
+	log.Debug("Processes found", map[string]interface{}{
+		"process_count": len(procs),
+	})
+
+	entries := sampleProcesses(ctx, procs)
+	if err := ctx.Err(); err != nil {
+		log.Error("Process information collection interrupted", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+
+	// Order by the requested key before enriching so that only the
+	// processes actually returned pay for the more expensive lookups
+	sort.SliceStable(entries, func(i, j int) bool {
+		if sortBy == ProcessSortMemory {
+			return entries[i].info.RSS > entries[j].info.RSS
+		}
+		return entries[i].info.CPUPercent > entries[j].info.CPUPercent
+	})
+
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	processes := make([]*Process, 0, len(entries))
+	for _, entry := range entries {
+		enrichProcess(ctx, entry)
+		processes = append(processes, entry.info)
+	}
+
+	log.Debug("Process information collection completed", map[string]interface{}{
+		"total_processes":    len(procs),
+		"returned_processes": len(processes),
+		"sort_by":            sortBy,
+	})
+
+	return processes, nil
+}
+
+// sampleProcesses computes CPU usage and resident memory for every process,
+// using the CPU time recorded on the previous collection as the baseline
+func sampleProcesses(ctx context.Context, procs []*process.Process) []*processEntry {
+	processSamplesMu.Lock()
+	defer processSamplesMu.Unlock()
+
+	now := time.Now()
+	seen := make(map[int32]bool, len(procs))
+	entries := make([]*processEntry, 0, len(procs))
+
+	for _, p := range procs {
+		if ctx.Err() != nil {
+			break
+		}
+
+		times, err := p.TimesWithContext(ctx)
+		if err != nil {
+			// Process exited or is not accessible, skip it
+			continue
+		}
+
+		info := &Process{PID: p.Pid}
+		cpuTotal := times.User + times.System
+
+		if prev, ok := processSamples[p.Pid]; ok {
+			elapsed := now.Sub(prev.at).Seconds()
+			if elapsed > 0 && cpuTotal >= prev.cpuTotal {
+				info.CPUPercent = (cpuTotal - prev.cpuTotal) / elapsed * 100
+			}
+			if now.Sub(prev.at) >= processMinWindow || cpuTotal < prev.cpuTotal {
+				processSamples[p.Pid] = processSample{cpuTotal: cpuTotal, at: now}
+			}
+		} else {
+			// First sighting: fall back to the lifetime average
+			if percent, err := p.CPUPercentWithContext(ctx); err == nil {
+				info.CPUPercent = percent
+			}
+			processSamples[p.Pid] = processSample{cpuTotal: cpuTotal, at: now}
+		}
+
+		if mem, err := p.MemoryInfoWithContext(ctx); err == nil {
+			info.RSS = mem.RSS
+		}
+
+		seen[p.Pid] = true
+		entries = append(entries, &processEntry{handle: p, info: info})
+	}
+
+	// Drop baselines for processes that no longer exist, unless the
+	// walk was cut short and some live processes were never visited
+	if ctx.Err() != nil {
+		return entries
+	}
+	for pid := range processSamples {
+		if !seen[pid] {
+			delete(processSamples, pid)
+		}
+	}
+
+	return entries
+}
+
+// enrichProcess fills in the descriptive fields of a process.
+// Fields that cannot be read (e.g. due to permissions) are left empty.
+func enrichProcess(ctx context.Context, entry *processEntry) {
+	p := entry.handle
+
+	if name, err := p.NameWithContext(ctx); err == nil {
+		entry.info.Name = name
+	}
+	if cmdline, err := p.CmdlineWithContext(ctx); err == nil {
+		entry.info.Cmdline = cmdline
+	}
+	if user, err := p.UsernameWithContext(ctx); err == nil {
+		entry.info.User = user
+	}
+	if threads, err := p.NumThreadsWithContext(ctx); err == nil {
+		entry.info.Threads = threads
+	}
+	if fds, err := p.NumFDsWithContext(ctx); err == nil {
+		entry.info.OpenFDs = fds
+	}
+}
@@ -0,0 +1,156 @@
+package echo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Alert statuses
+const (
+	StatusFiring       = "firing"
+	StatusAcknowledged = "acknowledged" // Still firing, but someone is on it
+	StatusResolved     = "resolved"
+)
+
+// Alert is a notification about one alert rule and label set
+// Handlers decide how to render it; Text gives a plain rendering for simple channels
+type Alert struct {
+	Rule        string            `json:"rule"`              // Rule name
+	Severity    string            `json:"severity"`          // "info", "warning" or "critical"
+	Status      string            `json:"status"`            // "firing", "acknowledged" or "resolved"
+	Metric      string            `json:"metric"`            // Evaluated metric
+	Op          string            `json:"op"`                // Comparison against Threshold
+	Value       float64           `json:"value"`             // Latest evaluated value
+	Threshold   float64           `json:"threshold"`         // Rule threshold
+	Peak        float64           `json:"peak"`              // Worst value observed while active
+	Labels      map[string]string `json:"labels"`            // Labels of the evaluated series
+	Host        string            `json:"host"`              // Hostname the alert was raised on
+	StartsAt    time.Time         `json:"startsAt"`          // When the condition was first met
+	EndsAt      time.Time         `json:"endsAt,omitzero"`   // When the alert resolved
+	Fingerprint string            `json:"fingerprint"`       // Stable identifier of rule and labels
+	Details     string            `json:"details,omitempty"` // Extra context such as top processes
+}
+
+// Embed colors used by chat handlers
+const (
+	ColorCritical = 0xE74C3C
+	ColorWarning  = 0xF39C12
+	ColorInfo     = 0x3498DB
+	ColorResolved = 0x2ECC71
+)
+
+// Resolved reports whether the alert has cleared
+func (a *Alert) Resolved() bool {
+	return a.Status == StatusResolved
+}
+
+// Acknowledged reports whether someone is handling the still firing alert
+func (a *Alert) Acknowledged() bool {
+	return a.Status == StatusAcknowledged
+}
+
+// Color returns the RGB color for the alert's severity, or green once resolved
+func (a *Alert) Color() int {
+	switch {
+	case a.Resolved():
+		return ColorResolved
+	case a.Severity == "critical":
+		return ColorCritical
+	case a.Severity == "warning":
+		return ColorWarning
+	}
+	return ColorInfo
+}
+
+// DedupKey identifies the incident of an alert in paging services
+// It is stable across repeats and restarts for the same host, rule and labels
+func (a *Alert) DedupKey() string {
+	if a.Host == "" {
+		return "delphos-" + a.Fingerprint
+	}
+	return "delphos-" + a.Host + "-" + a.Fingerprint
+}
+
+// Duration returns how long the condition lasted, or has lasted so far
+func (a *Alert) Duration() time.Duration {
+	end := a.EndsAt
+	if end.IsZero() {
+		end = time.Now()
+	}
+	return end.Sub(a.StartsAt).Round(time.Second)
+}
+
+// Series renders the metric and labels as metric{k=v, ...}
+func (a *Alert) Series() string {
+	if len(a.Labels) == 0 {
+		return a.Metric
+	}
+
+	keys := make([]string, 0, len(a.Labels))
+	for k := range a.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+a.Labels[k])
+	}
+	return a.Metric + "{" + strings.Join(parts, ", ") + "}"
+}
+
+// Summary renders the one line description shared by every text rendering
+func (a *Alert) Summary() string {
+	if a.Resolved() {
+		return fmt.Sprintf("%s is %.1f, lasted %s (peak %.1f)", a.Series(), a.Value, a.Duration(), a.Peak)
+	}
+	return fmt.Sprintf("%s is %.1f (%s %.1f)", a.Series(), a.Value, a.Op, a.Threshold)
+}
+
+// Text is the plain text fallback rendering of an alert
+func (a *Alert) Text() string {
+	text := fmt.Sprintf("[%s]: %s (%s)", strings.ToUpper(a.Status), a.Rule, a.Severity)
+	if a.Host != "" {
+		text += " on " + a.Host
+	}
+	text += ": " + a.Summary()
+	if a.Details != "" {
+		text += "\n" + a.Details
+	}
+	return text
+}
+
+// groupTitle is the one line heading of a notification carrying several alerts
+// Acknowledged alerts count as firing, as in email subjects
+func groupTitle(alerts []*Alert) string {
+	resolved := 0
+	host := alerts[0].Host
+	for _, a := range alerts {
+		if a.Resolved() {
+			resolved++
+		}
+		if a.Host != host {
+			host = ""
+		}
+	}
+
+	title := fmt.Sprintf("%d alerts (%d firing, %d resolved)", len(alerts), len(alerts)-resolved, resolved)
+	if host != "" {
+		title += " on " + host
+	}
+	return title
+}
+
+// truncate shortens s to at most limit bytes without splitting a character
+func truncate(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	for limit > 0 && !utf8.RuneStart(s[limit]) {
+		limit--
+	}
+	return s[:limit]
+}
@@ -1,6 +1,12 @@
 package echo
 
 import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -14,74 +20,174 @@ var (
 	once         sync.Once
 )
 
+// outboxDirName is the subdirectory of the data directory holding the outbox
+const outboxDirName = "outbox"
+
 type Echo struct {
-	Handlers         []Handler
-	cooldown         time.Duration
-	lastNotification time.Time
-	mu               sync.Mutex
-	logger           logger.BasicLogger
+	Handlers []Handler
+	names    []string // Handler names, parallel to Handlers
+	queues   []*Queue // One per handler once StartQueues has been called
+	outbox   *Outbox
+	options  QueueOptions
+	mu       sync.Mutex
+	logger   logger.BasicLogger
 }
 
-func (d *Echo) ShouldNotify() bool {
-	timeSince := time.Since(d.lastNotification)
-	shouldNotify := timeSince >= d.cooldown
-
-	d.logger.Debug("Checking if should notify", map[string]interface{}{
-		"time_since_last": timeSince.String(),
-		"cooldown":        d.cooldown.String(),
-		"should_notify":   shouldNotify,
-	})
-
-	return shouldNotify
+// Notify sends an alert to every handler
+// Repeat suppression is up to the caller (see the alerting engine)
+func (d *Echo) Notify(alert *Alert) error {
+	return d.Dispatch(alert, nil)
 }
 
-func (d *Echo) Notify(message string) error {
+// Dispatch sends an alert to the named handlers, or to every handler when names is nil
+// With queues started the alert is only queued, and delivery failures are
+// reported through Queues. Otherwise handlers are called in turn; a failing
+// handler does not stop the others and the failures are joined into the returned error.
+// Unknown names are reported in the error as well.
+func (d *Echo) Dispatch(alert *Alert, names []string) error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	d.logger.Info("Notification request received", map[string]interface{}{
-		"message_length": len(message),
-		"handlers_count": len(d.Handlers),
+	targets, errs := d.targets(names)
+
+	if len(d.queues) > 0 {
+		for _, i := range targets {
+			if err := d.queues[i].Push(alert); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", d.names[i], err))
+			}
+		}
+		return errors.Join(errs...)
+	}
+
+	d.logger.Info("Processing notification", map[string]interface{}{
+		"rule":           alert.Rule,
+		"status":         alert.Status,
+		"fingerprint":    alert.Fingerprint,
+		"handlers_count": len(targets),
 	})
 
-	if d.ShouldNotify() {
-		d.logger.Info("Processing notification", map[string]interface{}{
-			"message": message,
+	for _, i := range targets {
+		handler := d.Handlers[i]
+		d.logger.Debug("Sending to handler", map[string]interface{}{
+			"handler_index": i,
+			"handler_type":  getHandlerType(handler),
 		})
 
-		for i, handler := range d.Handlers {
-			d.logger.Debug("Sending to handler", map[string]interface{}{
+		if err := handler.Handle(alert); err != nil {
+			d.logger.Error("Handler failed to process notification", map[string]interface{}{
+				"handler_index": i,
+				"handler_type":  getHandlerType(handler),
+				"error":         err.Error(),
+			})
+			errs = append(errs, fmt.Errorf("%s: %w", d.names[i], err))
+		} else {
+			d.logger.Debug("Handler processed notification successfully", map[string]interface{}{
 				"handler_index": i,
 				"handler_type":  getHandlerType(handler),
 			})
+		}
+	}
+
+	return errors.Join(errs...)
+}
 
-			if err := handler.Handle(message); err != nil {
-				d.logger.Error("Handler failed to process notification", map[string]interface{}{
-					"handler_index": i,
-					"handler_type":  getHandlerType(handler),
-					"error":         err.Error(),
-				})
-			} else {
-				d.logger.Debug("Handler processed notification successfully", map[string]interface{}{
-					"handler_index": i,
-					"handler_type":  getHandlerType(handler),
-				})
+// DispatchGroup sends alerts to the named handlers as one notification
+// A BatchHandler receives the alerts in one call; other handlers get them one
+// by one. Names, queues and errors are treated as in Dispatch.
+func (d *Echo) DispatchGroup(alerts []*Alert, names []string) error {
+	switch len(alerts) {
+	case 0:
+		return nil
+	case 1:
+		return d.Dispatch(alerts[0], names)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	targets, errs := d.targets(names)
+
+	if len(d.queues) > 0 {
+		for _, i := range targets {
+			if err := d.queues[i].PushGroup(alerts); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", d.names[i], err))
 			}
 		}
+		return errors.Join(errs...)
+	}
 
-		d.lastNotification = time.Now()
-		d.logger.Info("Notification processed successfully", map[string]interface{}{
-			"last_notification": d.lastNotification.Format(time.RFC3339),
-		})
-	} else {
-		timeUntilNext := d.cooldown - time.Since(d.lastNotification)
-		d.logger.Debug("Notification skipped due to cooldown", map[string]interface{}{
-			"time_until_next":   timeUntilNext.String(),
-			"last_notification": d.lastNotification.Format(time.RFC3339),
-		})
+	d.logger.Info("Processing notification group", map[string]interface{}{
+		"rule":           alerts[0].Rule,
+		"alerts":         len(alerts),
+		"handlers_count": len(targets),
+	})
+
+	for _, i := range targets {
+		handler := d.Handlers[i]
+
+		var err error
+		if batcher, ok := handler.(BatchHandler); ok {
+			err = batcher.HandleBatch(alerts)
+		} else {
+			var failed []error
+			for _, alert := range alerts {
+				if err := handler.Handle(alert); err != nil {
+					failed = append(failed, err)
+				}
+			}
+			err = errors.Join(failed...)
+		}
+
+		if err != nil {
+			d.logger.Error("Handler failed to process notification group", map[string]interface{}{
+				"handler_index": i,
+				"handler_type":  getHandlerType(handler),
+				"error":         err.Error(),
+			})
+			errs = append(errs, fmt.Errorf("%s: %w", d.names[i], err))
+		}
 	}
 
-	return nil
+	return errors.Join(errs...)
+}
+
+// HandlerNames returns the names Dispatch accepts, in handler order
+// A name is derived from the handler type (e.g. "discord", "pagerduty")
+func (d *Echo) HandlerNames() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return append([]string(nil), d.names...)
+}
+
+// targets resolves handler names to indices; callers hold the lock
+func (d *Echo) targets(names []string) ([]int, []error) {
+	if names == nil {
+		all := make([]int, len(d.Handlers))
+		for i := range all {
+			all[i] = i
+		}
+		return all, nil
+	}
+
+	var (
+		targets []int
+		errs    []error
+	)
+	for _, name := range names {
+		found := false
+		for i, known := range d.names {
+			if known == name {
+				targets = append(targets, i)
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, fmt.Errorf("unknown handler %q", name))
+		}
+	}
+	return targets, errs
 }
 
 func (d *Echo) AddHandler(handler Handler) {
@@ -90,23 +196,168 @@ func (d *Echo) AddHandler(handler Handler) {
 		"total_handlers": len(d.Handlers) + 1,
 	})
 
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	name := handlerName(handler, d.names)
 	d.Handlers = append(d.Handlers, handler)
+	d.names = append(d.names, name)
+	if d.queues != nil {
+		queue := NewQueue(name, handler, d.outbox, d.options)
+		d.queues = append(d.queues, queue)
+		queue.Start()
+	}
+}
+
+// StartQueues switches Dispatch to asynchronous delivery through one queue per handler
+// outbox may be nil to keep queued alerts in memory only
+func (d *Echo) StartQueues(outbox *Outbox, options QueueOptions) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.outbox = outbox
+	d.options = options
+	d.queues = make([]*Queue, 0, len(d.Handlers))
+	for i, handler := range d.Handlers {
+		d.queues = append(d.queues, NewQueue(d.names[i], handler, outbox, options))
+	}
+
+	if outbox != nil {
+		d.reportOrphans()
+	}
+	for _, queue := range d.queues {
+		queue.Start()
+	}
+
+	d.logger.Info("Notification queues started", map[string]interface{}{
+		"queues":       len(d.queues),
+		"workers":      options.Workers,
+		"size":         options.Size,
+		"max_attempts": options.MaxAttempts,
+		"backoff":      options.Backoff.String(),
+		"persistent":   outbox != nil,
+	})
+}
+
+// Queues returns the state of every handler queue, empty while delivery is synchronous
+func (d *Echo) Queues() []QueueStats {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stats := make([]QueueStats, 0, len(d.queues))
+	for _, queue := range d.queues {
+		stats = append(stats, queue.Stats())
+	}
+	return stats
+}
+
+// reportOrphans logs outbox entries left for handlers that are no longer configured
+// They are kept in case the handler is configured again
+func (d *Echo) reportOrphans() {
+	names, err := d.outbox.Handlers()
+	if err != nil {
+		return
+	}
+
+	known := make(map[string]bool, len(d.queues))
+	for _, queue := range d.queues {
+		known[queue.name] = true
+	}
+	for _, name := range names {
+		if !known[name] {
+			d.logger.Warn("Outbox holds notifications for a handler that is not configured", map[string]interface{}{
+				"handler": name,
+			})
+		}
+	}
+}
+
+// handlerName derives a stable name from the handler type (e.g. "discord")
+// A second handler of the same type gets a numeric suffix
+func handlerName(handler Handler, taken []string) string {
+	base := strings.ToLower(strings.TrimSuffix(getHandlerType(handler), "Handler"))
+	name := base
+	for n := 2; slices.Contains(taken, name); n++ {
+		name = base + "-" + strconv.Itoa(n)
+	}
+	return name
 }
 
 func New() Notifier {
 	net := hermes.GetInstance()
 	log := logger.GetInstance()
 
-	log.Info("Initializing Echo notification system", map[string]interface{}{
-		"cooldown": config.Env.Cooldown,
+	log.Info("Initializing Echo notification system", map[string]interface{}{})
+
+	handlers := []Handler{
+		NewDiscordHandler(net),
+	}
+	if config.Env.SlackWebhookUrl != "" {
+		handlers = append(handlers, NewSlackHandler(net))
+	}
+	if config.Env.AlertWebhookUrl != "" {
+		handlers = append(handlers, NewWebhookHandler(net))
+	}
+	if config.Env.SMTPHost != "" {
+		handlers = append(handlers, NewSMTPHandler())
+	}
+	if config.Env.PagerDutyRoutingKey != "" {
+		handlers = append(handlers, NewPagerDutyHandler(net))
+	}
+	if config.Env.OpsgenieApiKey != "" {
+		handlers = append(handlers, NewOpsgenieHandler(net))
+	}
+	if config.Env.TelegramBotToken != "" && config.Env.TelegramChatID != "" {
+		handlers = append(handlers, NewTelegramHandler(net))
+	}
+	if config.Env.NtfyTopic != "" {
+		handlers = append(handlers, NewNtfyHandler(net))
+	}
+	if config.Env.GotifyUrl != "" && config.Env.GotifyToken != "" {
+		handlers = append(handlers, NewGotifyHandler(net))
+	}
+	if config.Env.ExecCommand != "" {
+		handlers = append(handlers, NewCommandHandler())
+	}
+
+	notifier := NewWithHandlers(handlers)
+
+	var outbox *Outbox
+	if config.Env.DataDir != "" {
+		dir := filepath.Join(config.Env.DataDir, outboxDirName)
+		opened, err := OpenOutbox(dir)
+		if err != nil {
+			log.Error("Failed to open notification outbox, queued alerts will not persist", map[string]interface{}{
+				"dir":   dir,
+				"error": err.Error(),
+			})
+		} else {
+			outbox = opened
+		}
+	}
+
+	notifier.StartQueues(outbox, QueueOptions{
+		Workers:     config.Env.NotifyWorkers,
+		Size:        config.Env.NotifyQueueSize,
+		MaxAttempts: config.Env.NotifyMaxAttempts,
+		Backoff:     time.Duration(config.Env.NotifyRetryBackoff) * time.Second,
 	})
 
+	return notifier
+}
+
+// NewWithHandlers creates a notifier calling the given handlers synchronously
+// until StartQueues is called
+func NewWithHandlers(handlers []Handler) *Echo {
+	names := make([]string, 0, len(handlers))
+	for _, handler := range handlers {
+		names = append(names, handlerName(handler, names))
+	}
+
 	return &Echo{
-		Handlers: []Handler{
-			NewDiscordHandler(net),
-		},
-		cooldown: time.Duration(config.Env.Cooldown) * time.Second,
-		logger:   log,
+		Handlers: handlers,
+		names:    names,
+		logger:   logger.GetInstance(),
 	}
 }
 
@@ -122,6 +373,24 @@ func getHandlerType(handler Handler) string {
 	switch handler.(type) {
 	case *DiscordHandler:
 		return "DiscordHandler"
+	case *SlackHandler:
+		return "SlackHandler"
+	case *WebhookHandler:
+		return "WebhookHandler"
+	case *EmailHandler:
+		return "EmailHandler"
+	case *PagerDutyHandler:
+		return "PagerDutyHandler"
+	case *OpsgenieHandler:
+		return "OpsgenieHandler"
+	case *TelegramHandler:
+		return "TelegramHandler"
+	case *NtfyHandler:
+		return "NtfyHandler"
+	case *GotifyHandler:
+		return "GotifyHandler"
+	case *ExecHandler:
+		return "ExecHandler"
 	default:
 		return "UnknownHandler"
 	}
@@ -0,0 +1,314 @@
+package echo
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/LissaiDev/Delphos/internal/config"
+	"github.com/LissaiDev/Delphos/pkg/logger"
+)
+
+// EmailData is passed to the email body templates
+type EmailData struct {
+	Subject  string   // Subject line of the message
+	Host     string   // Host of the first alert
+	Alerts   []*Alert // Alerts in the order they were raised
+	Firing   int      // Number of firing alerts
+	Resolved int      // Number of resolved alerts
+}
+
+// defaultEmailText is the built-in plain text body
+const defaultEmailText = `{{ range .Alerts }}{{ .Text }}
+  Started:     {{ .StartsAt.Format "2006-01-02 15:04:05 MST" }}
+{{- if .Resolved }}
+  Resolved:    {{ .EndsAt.Format "2006-01-02 15:04:05 MST" }}
+{{- end }}
+  Fingerprint: {{ .Fingerprint }}
+
+{{ end }}--
+Sent by Delphos
+`
+
+// defaultEmailHTML is the built-in HTML body
+const defaultEmailHTML = `<!DOCTYPE html>
+<html>
+<body style="font-family: sans-serif; color: #222;">
+<h2 style="margin: 0 0 16px;">{{ .Subject }}</h2>
+{{ range .Alerts }}
+<table style="border-left: 6px solid {{ color . }}; margin-bottom: 16px; padding: 4px 12px; border-collapse: collapse;">
+  <tr><td colspan="2" style="font-size: 16px; font-weight: bold; padding: 4px 0;">{{ if .Resolved }}RESOLVED{{ else if .Acknowledged }}ACKNOWLEDGED{{ else }}FIRING{{ end }}: {{ .Rule }}</td></tr>
+  <tr><td style="padding-right: 16px;">Severity</td><td>{{ .Severity }}</td></tr>
+  <tr><td style="padding-right: 16px;">Host</td><td>{{ .Host }}</td></tr>
+  <tr><td style="padding-right: 16px;">Metric</td><td><code>{{ .Series }}</code></td></tr>
+  <tr><td style="padding-right: 16px;">Value</td><td>{{ printf "%.1f" .Value }}</td></tr>
+  {{- if .Resolved }}
+  <tr><td style="padding-right: 16px;">Peak</td><td>{{ printf "%.1f" .Peak }}</td></tr>
+  <tr><td style="padding-right: 16px;">Duration</td><td>{{ .Duration }}</td></tr>
+  {{- else }}
+  <tr><td style="padding-right: 16px;">Threshold</td><td>{{ .Op }} {{ printf "%.1f" .Threshold }}</td></tr>
+  {{- end }}
+  <tr><td style="padding-right: 16px;">Started</td><td>{{ .StartsAt.Format "2006-01-02 15:04:05 MST" }}</td></tr>
+  {{- if .Details }}
+  <tr><td colspan="2" style="padding-top: 8px; white-space: pre-wrap;">{{ .Details }}</td></tr>
+  {{- end }}
+</table>
+{{ end }}
+<p style="color: #888; font-size: 12px;">Sent by Delphos</p>
+</body>
+</html>
+`
+
+// emailTemplateFuncs are available to both body templates
+var emailTemplateFuncs = map[string]any{
+	// color renders the alert color as a CSS hex value
+	"color": func(a *Alert) string {
+		return fmt.Sprintf("#%06X", a.Color())
+	},
+}
+
+// EmailHandler sends alerts by email
+// With a delivery queue, alerts raised within the batch window are collected
+// into a single message (see WindowedHandler)
+type EmailHandler struct {
+	transport MailTransport
+	from      string   // From header, possibly with a display name
+	to        []string // To header entries, possibly with display names
+	sender    string   // Bare envelope sender address
+	rcpts     []string // Bare envelope recipient addresses
+	window    time.Duration
+	text      *template.Template
+	html      *htmltemplate.Template
+	logger    logger.BasicLogger
+}
+
+// Handle sends the alert in a message of its own
+func (e *EmailHandler) Handle(alert *Alert) error {
+	return e.send([]*Alert{alert})
+}
+
+// HandleBatch sends the alerts in one message
+func (e *EmailHandler) HandleBatch(alerts []*Alert) error {
+	if len(alerts) == 0 {
+		return nil
+	}
+	return e.send(alerts)
+}
+
+// BatchWindow returns how long alerts are collected into one message
+func (e *EmailHandler) BatchWindow() time.Duration {
+	return e.window
+}
+
+// send renders and delivers one message holding the given alerts
+func (e *EmailHandler) send(alerts []*Alert) error {
+	message, err := e.BuildMessage(alerts, time.Now())
+	if err != nil {
+		e.logger.Error("Failed to render alert email", map[string]interface{}{
+			"alerts": len(alerts),
+			"error":  err.Error(),
+		})
+		return err
+	}
+
+	if err := e.transport.Send(e.sender, e.rcpts, message); err != nil {
+		e.logger.Error("Failed to send alert email", map[string]interface{}{
+			"alerts":     len(alerts),
+			"recipients": len(e.to),
+			"error":      err.Error(),
+		})
+		return fmt.Errorf("smtp delivery failed: %w", err)
+	}
+
+	e.logger.Info("Alert email sent successfully", map[string]interface{}{
+		"alerts":     len(alerts),
+		"recipients": len(e.to),
+	})
+
+	return nil
+}
+
+// BuildMessage renders a multipart/alternative message with text and HTML bodies
+func (e *EmailHandler) BuildMessage(alerts []*Alert, now time.Time) ([]byte, error) {
+	data := &EmailData{Alerts: alerts, Host: alerts[0].Host}
+	for _, alert := range alerts {
+		if alert.Resolved() {
+			data.Resolved++
+		} else {
+			data.Firing++
+		}
+	}
+	data.Subject = emailSubject(data)
+
+	var text, html bytes.Buffer
+	if err := e.text.Execute(&text, data); err != nil {
+		return nil, err
+	}
+	if err := e.html.Execute(&html, data); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	parts := multipart.NewWriter(&msg)
+
+	headers := []string{
+		"From: " + e.from,
+		"To: " + strings.Join(e.to, ", "),
+		"Subject: " + mime.QEncoding.Encode("utf-8", data.Subject),
+		"Date: " + now.Format(time.RFC1123Z),
+		fmt.Sprintf("Message-ID: <%d.%s@delphos>", now.UnixNano(), alerts[0].Fingerprint),
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/alternative; boundary=" + parts.Boundary(),
+	}
+	msg.WriteString(strings.Join(headers, "\r\n") + "\r\n\r\n")
+
+	for _, body := range []struct {
+		contentType string
+		content     []byte
+	}{
+		{"text/plain; charset=utf-8", text.Bytes()},
+		{"text/html; charset=utf-8", html.Bytes()},
+	} {
+		part, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {body.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(part)
+		if _, err := qp.Write(body.content); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+	return msg.Bytes(), nil
+}
+
+// emailSubject summarizes the alerts of a message
+func emailSubject(data *EmailData) string {
+	if len(data.Alerts) == 1 {
+		a := data.Alerts[0]
+		subject := fmt.Sprintf("[%s] %s (%s)", strings.ToUpper(a.Status), a.Rule, a.Severity)
+		if a.Host != "" {
+			subject += " on " + a.Host
+		}
+		return subject
+	}
+
+	subject := fmt.Sprintf("[Delphos] %d alerts (%d firing, %d resolved)", len(data.Alerts), data.Firing, data.Resolved)
+	if data.Host != "" {
+		subject += " on " + data.Host
+	}
+	return subject
+}
+
+// NewEmailHandler creates an email handler sending through the given transport
+// Addresses may carry display names (e.g. "Delphos <alerts@example.com>"),
+// which are kept in the headers and stripped for the SMTP envelope
+func NewEmailHandler(transport MailTransport, from string, to []string, window time.Duration) *EmailHandler {
+	rcpts := make([]string, 0, len(to))
+	for _, addr := range to {
+		rcpts = append(rcpts, envelopeAddress(addr))
+	}
+
+	return &EmailHandler{
+		transport: transport,
+		from:      from,
+		to:        to,
+		sender:    envelopeAddress(from),
+		rcpts:     rcpts,
+		window:    window,
+		text:      template.Must(template.New("text").Funcs(emailTemplateFuncs).Parse(defaultEmailText)),
+		html:      htmltemplate.Must(htmltemplate.New("html").Funcs(emailTemplateFuncs).Parse(defaultEmailHTML)),
+		logger:    logger.GetInstance(),
+	}
+}
+
+// NewSMTPHandler creates an email handler configured from config.Env
+func NewSMTPHandler() Handler {
+	log := logger.GetInstance()
+	cfg := config.Env
+
+	transport := &SMTPTransport{
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+		Auth:     cfg.SMTPAuth,
+		TLS:      cfg.SMTPTLS,
+	}
+	handler := NewEmailHandler(transport, cfg.SMTPFrom, cfg.SMTPTo, time.Duration(cfg.SMTPBatchWindow)*time.Second)
+
+	if cfg.SMTPTextTemplate != "" {
+		tmpl, err := readTemplateFile(cfg.SMTPTextTemplate)
+		if err == nil {
+			handler.text, err = template.New("text").Funcs(emailTemplateFuncs).Parse(tmpl)
+		}
+		if err != nil {
+			log.Error("Failed to load email text template, using the built-in one", map[string]interface{}{
+				"path":  cfg.SMTPTextTemplate,
+				"error": err.Error(),
+			})
+			handler.text = template.Must(template.New("text").Funcs(emailTemplateFuncs).Parse(defaultEmailText))
+		}
+	}
+	if cfg.SMTPHTMLTemplate != "" {
+		tmpl, err := readTemplateFile(cfg.SMTPHTMLTemplate)
+		if err == nil {
+			handler.html, err = htmltemplate.New("html").Funcs(emailTemplateFuncs).Parse(tmpl)
+		}
+		if err != nil {
+			log.Error("Failed to load email HTML template, using the built-in one", map[string]interface{}{
+				"path":  cfg.SMTPHTMLTemplate,
+				"error": err.Error(),
+			})
+			handler.html = htmltemplate.Must(htmltemplate.New("html").Funcs(emailTemplateFuncs).Parse(defaultEmailHTML))
+		}
+	}
+
+	log.Info("Creating SMTP handler", map[string]interface{}{
+		"host":         cfg.SMTPHost,
+		"port":         cfg.SMTPPort,
+		"tls":          cfg.SMTPTLS,
+		"auth":         cfg.SMTPUsername != "",
+		"recipients":   len(cfg.SMTPTo),
+		"batch_window": handler.window.String(),
+	})
+
+	return handler
+}
+
+// envelopeAddress returns the bare address of an RFC 5322 address
+// Unparseable values are returned as they are and left for the server to reject
+func envelopeAddress(addr string) string {
+	parsed, err := mail.ParseAddress(addr)
+	if err != nil {
+		return addr
+	}
+	return parsed.Address
+}
+
+// readTemplateFile reads a template file into a string
+func readTemplateFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
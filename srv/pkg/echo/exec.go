@@ -0,0 +1,200 @@
+package echo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LissaiDev/Delphos/internal/config"
+	"github.com/LissaiDev/Delphos/pkg/logger"
+)
+
+// execOutputLimit is the most output kept per stream of a run
+const execOutputLimit = 16 * 1024
+
+// execWaitDelay is how long a killed run may hold its output pipes open
+const execWaitDelay = 2 * time.Second
+
+// ExecHandler runs a command for every alert
+// The alert is passed as DELPHOS_* environment variables and as a WebhookPayload
+// on stdin. A run that exits non-zero or outlives the timeout fails the handler.
+type ExecHandler struct {
+	command string
+	args    []string
+	timeout time.Duration
+	slots   chan struct{} // Bounds the number of runs at the same time
+	logger  logger.BasicLogger
+}
+
+func (e *ExecHandler) Handle(alert *Alert) error {
+	e.logger.Debug("Exec handler processing alert", map[string]interface{}{
+		"rule":    alert.Rule,
+		"status":  alert.Status,
+		"command": e.command,
+	})
+
+	stdin, err := json.Marshal(&WebhookPayload{
+		Version: WebhookPayloadVersion,
+		Text:    alert.Text(),
+		Alert:   alert,
+	})
+	if err != nil {
+		return err
+	}
+
+	e.slots <- struct{}{}
+	defer func() { <-e.slots }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, e.command, e.args...)
+	cmd.Env = append(os.Environ(), ExecEnv(alert)...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.WaitDelay = execWaitDelay
+
+	stdout := &limitedBuffer{limit: execOutputLimit}
+	stderr := &limitedBuffer{limit: execOutputLimit}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	started := time.Now()
+	err = cmd.Run()
+	fields := map[string]interface{}{
+		"rule":     alert.Rule,
+		"status":   alert.Status,
+		"command":  e.command,
+		"duration": time.Since(started).String(),
+		"stdout":   stdout.String(),
+		"stderr":   stderr.String(),
+	}
+
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("exec command timed out after %s", e.timeout)
+		} else {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				err = fmt.Errorf("exec command exited with status %d", exitErr.ExitCode())
+			} else {
+				err = fmt.Errorf("exec command failed: %w", err)
+			}
+		}
+		fields["error"] = err.Error()
+		e.logger.Error("Exec notification command failed", fields)
+		return err
+	}
+
+	e.logger.Info("Exec notification command finished successfully", fields)
+	return nil
+}
+
+// ExecEnv returns the environment variables describing an alert
+// Every label is also exported as DELPHOS_LABEL_<NAME>, upper cased with
+// characters other than letters, digits and underscores replaced by underscores
+func ExecEnv(alert *Alert) []string {
+	labels, _ := json.Marshal(alert.Labels)
+
+	env := []string{
+		"DELPHOS_RULE=" + alert.Rule,
+		"DELPHOS_SEVERITY=" + alert.Severity,
+		"DELPHOS_STATUS=" + alert.Status,
+		"DELPHOS_METRIC=" + alert.Metric,
+		"DELPHOS_SERIES=" + alert.Series(),
+		"DELPHOS_OP=" + alert.Op,
+		"DELPHOS_VALUE=" + strconv.FormatFloat(alert.Value, 'f', -1, 64),
+		"DELPHOS_THRESHOLD=" + strconv.FormatFloat(alert.Threshold, 'f', -1, 64),
+		"DELPHOS_PEAK=" + strconv.FormatFloat(alert.Peak, 'f', -1, 64),
+		"DELPHOS_HOST=" + alert.Host,
+		"DELPHOS_FINGERPRINT=" + alert.Fingerprint,
+		"DELPHOS_DEDUP_KEY=" + alert.DedupKey(),
+		"DELPHOS_STARTS_AT=" + alert.StartsAt.Format(time.RFC3339),
+		"DELPHOS_SUMMARY=" + alert.Summary(),
+		"DELPHOS_DETAILS=" + alert.Details,
+		"DELPHOS_LABELS=" + string(labels),
+	}
+	if alert.Resolved() {
+		env = append(env,
+			"DELPHOS_ENDS_AT="+alert.EndsAt.Format(time.RFC3339),
+			"DELPHOS_DURATION="+alert.Duration().String(),
+		)
+	}
+	for k, v := range alert.Labels {
+		env = append(env, "DELPHOS_LABEL_"+envName(k)+"="+v)
+	}
+	return env
+}
+
+// envName turns a label name into an environment variable name suffix
+func envName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// limitedBuffer keeps the first limit bytes written to it and drops the rest
+// Writes never fail, so a chatty command is not killed by a broken pipe
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.buf.Len(); room < len(p) {
+		b.truncated = true
+		if room > 0 {
+			b.buf.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) String() string {
+	s := strings.TrimRight(b.buf.String(), "\n")
+	if b.truncated {
+		s += "... (truncated)"
+	}
+	return s
+}
+
+// NewExecHandler creates a handler running command with args
+// At most concurrency runs happen at once; further alerts wait for a free slot
+func NewExecHandler(command string, args []string, timeout time.Duration, concurrency int) *ExecHandler {
+	return &ExecHandler{
+		command: command,
+		args:    args,
+		timeout: timeout,
+		slots:   make(chan struct{}, concurrency),
+		logger:  logger.GetInstance(),
+	}
+}
+
+// NewCommandHandler creates an exec handler configured from config.Env
+func NewCommandHandler() Handler {
+	log := logger.GetInstance()
+	cfg := config.Env
+
+	log.Info("Creating exec handler", map[string]interface{}{
+		"command":     cfg.ExecCommand,
+		"args":        cfg.ExecArgs,
+		"timeout":     cfg.ExecTimeout,
+		"concurrency": cfg.ExecConcurrency,
+	})
+
+	return NewExecHandler(cfg.ExecCommand, cfg.ExecArgs, time.Duration(cfg.ExecTimeout)*time.Second, cfg.ExecConcurrency)
+}
@@ -2,6 +2,7 @@ package echo
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/LissaiDev/Delphos/internal/config"
 	"github.com/LissaiDev/Delphos/pkg/hermes"
@@ -19,22 +20,61 @@ type DiscordHandler struct {
 	logger      logger.BasicLogger
 }
 
-func (d *DiscordHandler) Handle(message string) error {
-	d.logger.Debug("Discord handler processing message", map[string]interface{}{
-		"message_length": len(message),
-		"webhook_url":    d.webhookData.url,
-		"username":       d.webhookData.username,
+func (d *DiscordHandler) Handle(alert *Alert) error {
+	d.logger.Debug("Discord handler processing alert", map[string]interface{}{
+		"rule":        alert.Rule,
+		"status":      alert.Status,
+		"webhook_url": d.webhookData.url,
+		"username":    d.webhookData.username,
 	})
 
 	if d.webhookData.url == "" {
 		d.logger.Warn("Discord webhook URL not configured, skipping notification", map[string]interface{}{
-			"message": message,
+			"message": alert.Text(),
 		})
 		return nil
 	}
 
-	body := d.BuildBody(message)
+	return d.send(d.BuildBody(alert), alert.Text())
+}
+
+// discordGroupEmbeds is the number of alerts sent per message by HandleBatch
+// Discord allows 10 embeds and 6000 characters per message, and alert details
+// can be long
+const discordGroupEmbeds = 5
 
+// HandleBatch sends the alerts as the embeds of as few messages as Discord allows
+func (d *DiscordHandler) HandleBatch(alerts []*Alert) error {
+	if d.webhookData.url == "" {
+		d.logger.Warn("Discord webhook URL not configured, skipping notification", map[string]interface{}{
+			"message": groupTitle(alerts),
+		})
+		return nil
+	}
+
+	for start := 0; start < len(alerts); start += discordGroupEmbeds {
+		chunk := alerts[start:min(start+discordGroupEmbeds, len(alerts))]
+		embeds := make([]map[string]any, len(chunk))
+		for i, alert := range chunk {
+			embeds[i] = d.embed(alert)
+		}
+
+		body := map[string]any{
+			"username": d.webhookData.username,
+			"embeds":   embeds,
+		}
+		if start == 0 {
+			body["content"] = groupTitle(alerts)
+		}
+		if err := d.send(&body, groupTitle(chunk)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// send posts a message body to the webhook; message describes it in logs
+func (d *DiscordHandler) send(body *map[string]any, message string) error {
 	d.logger.Debug("Sending Discord webhook", map[string]interface{}{
 		"url":      d.webhookData.url,
 		"username": d.webhookData.username,
@@ -61,11 +101,64 @@ func (d *DiscordHandler) Handle(message string) error {
 	return nil
 }
 
-func (d *DiscordHandler) BuildBody(message string) *map[string]any {
+// BuildBody renders an alert as a Discord embed
+func (d *DiscordHandler) BuildBody(alert *Alert) *map[string]any {
 	return &map[string]any{
 		"username": d.webhookData.username,
-		"content":  message,
+		"embeds":   []map[string]any{d.embed(alert)},
+	}
+}
+
+// embed renders the embed of an alert
+// Firing alerts are colored by severity and resolved alerts are green
+func (d *DiscordHandler) embed(alert *Alert) map[string]any {
+	title := "\U0001F6A8 FIRING: " + alert.Rule
+	if alert.Resolved() {
+		title = "✅ RESOLVED: " + alert.Rule
+	} else if alert.Acknowledged() {
+		title = "\U0001F440 ACKNOWLEDGED: " + alert.Rule
+	}
+
+	fields := []map[string]any{
+		{"name": "Severity", "value": alert.Severity, "inline": true},
+		{"name": "Host", "value": orDash(alert.Host), "inline": true},
+		{"name": "Metric", "value": alert.Series(), "inline": false},
+		{"name": "Value", "value": fmt.Sprintf("%.1f", alert.Value), "inline": true},
+	}
+	if alert.Resolved() {
+		fields = append(fields,
+			map[string]any{"name": "Peak", "value": fmt.Sprintf("%.1f", alert.Peak), "inline": true},
+			map[string]any{"name": "Duration", "value": alert.Duration().String(), "inline": true},
+		)
+	} else {
+		fields = append(fields,
+			map[string]any{"name": "Threshold", "value": fmt.Sprintf("%s %.1f", alert.Op, alert.Threshold), "inline": true},
+		)
+	}
+
+	embed := map[string]any{
+		"title":     title,
+		"color":     alert.Color(),
+		"fields":    fields,
+		"timestamp": alert.StartsAt.Format(time.RFC3339),
+		"footer":    map[string]any{"text": alert.Fingerprint},
+	}
+	if alert.Resolved() {
+		embed["timestamp"] = alert.EndsAt.Format(time.RFC3339)
+	}
+	if alert.Details != "" {
+		embed["description"] = alert.Details
+	}
+
+	return embed
+}
+
+// orDash substitutes a dash for empty embed field values, which Discord rejects
+func orDash(s string) string {
+	if s == "" {
+		return "-"
 	}
+	return s
 }
 
 func NewDiscordHandler(net hermes.Fetcher) Handler {
@@ -1,9 +1,33 @@
 package echo
 
+import "time"
+
 type Notifier interface {
-	Notify(message string) error
+	Notify(alert *Alert) error
+}
+
+// Dispatcher is a Notifier that can also notify a subset of its handlers by name
+type Dispatcher interface {
+	Notifier
+	Dispatch(alert *Alert, names []string) error
+	DispatchGroup(alerts []*Alert, names []string) error
+	HandlerNames() []string
 }
 
 type Handler interface {
-	Handle(message string) error
+	Handle(alert *Alert) error
+}
+
+// BatchHandler is a Handler that can deliver several alerts in one notification
+type BatchHandler interface {
+	Handler
+	HandleBatch(alerts []*Alert) error
+}
+
+// WindowedHandler is a BatchHandler that also batches alerts raised close together
+// Delivery queues collect the alerts raised within BatchWindow of the oldest
+// waiting one and hand them over together; they stay queued until sent
+type WindowedHandler interface {
+	BatchHandler
+	BatchWindow() time.Duration
 }
@@ -0,0 +1,141 @@
+package echo
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/LissaiDev/Delphos/internal/config"
+	"github.com/LissaiDev/Delphos/pkg/hermes"
+	"github.com/LissaiDev/Delphos/pkg/logger"
+)
+
+// opsgenieService is the hermes service for the Opsgenie Alert API
+const opsgenieService = hermes.Service("OPSGENIE")
+
+// opsgenieMessageLimit is the longest message Opsgenie accepts
+const opsgenieMessageLimit = 130
+
+// OpsgenieHandler sends alerts to the Opsgenie Alert API
+// Alerts are created with the dedup key as alias, and acknowledged or closed by that alias
+type OpsgenieHandler struct {
+	net    hermes.Fetcher
+	apiKey string
+	logger logger.BasicLogger
+}
+
+func (o *OpsgenieHandler) Handle(alert *Alert) error {
+	path, body := o.BuildRequest(alert)
+
+	o.logger.Debug("Opsgenie handler processing alert", map[string]interface{}{
+		"rule":  alert.Rule,
+		"path":  path,
+		"alias": alert.DedupKey(),
+	})
+
+	headers := map[string]string{
+		"Content-Type":  "application/json",
+		"Authorization": "GenieKey " + o.apiKey,
+	}
+
+	response := o.net.Post(opsgenieService, path, body, &headers)
+	if !response.Success || response.Code >= 300 {
+		o.logger.Error("Failed to send Opsgenie request", map[string]interface{}{
+			"status_code": response.Code,
+			"response":    string(response.Data),
+			"rule":        alert.Rule,
+			"path":        path,
+		})
+		return fmt.Errorf("opsgenie request failed with status code: %d", response.Code)
+	}
+
+	o.logger.Info("Opsgenie request sent successfully", map[string]interface{}{
+		"rule":        alert.Rule,
+		"status":      alert.Status,
+		"alias":       alert.DedupKey(),
+		"status_code": response.Code,
+	})
+
+	return nil
+}
+
+// BuildRequest returns the API path and body for an alert
+// Firing alerts create (or deduplicate into) an Opsgenie alert, the others act on it by alias
+func (o *OpsgenieHandler) BuildRequest(alert *Alert) (string, *map[string]any) {
+	alias := url.PathEscape(alert.DedupKey())
+
+	switch alert.Status {
+	case StatusResolved:
+		return "/v2/alerts/" + alias + "/close?identifierType=alias", &map[string]any{
+			"source": "Delphos",
+			"note":   alert.Summary(),
+		}
+	case StatusAcknowledged:
+		return "/v2/alerts/" + alias + "/acknowledge?identifierType=alias", &map[string]any{
+			"source": "Delphos",
+		}
+	}
+
+	message := truncate(alert.Rule+": "+alert.Summary(), opsgenieMessageLimit)
+
+	details := map[string]string{
+		"metric":      alert.Metric,
+		"value":       strconv.FormatFloat(alert.Value, 'f', -1, 64),
+		"threshold":   alert.Op + " " + strconv.FormatFloat(alert.Threshold, 'f', -1, 64),
+		"severity":    alert.Severity,
+		"fingerprint": alert.Fingerprint,
+	}
+	for k, v := range alert.Labels {
+		details["label_"+k] = v
+	}
+
+	tags := []string{"delphos", alert.Rule, alert.Severity}
+	keys := make([]string, 0, len(alert.Labels))
+	for k := range alert.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		tags = append(tags, k+":"+alert.Labels[k])
+	}
+
+	return "/v2/alerts", &map[string]any{
+		"message":     message,
+		"alias":       alert.DedupKey(),
+		"description": alert.Text(),
+		"tags":        tags,
+		"details":     details,
+		"entity":      alert.Host,
+		"source":      "Delphos",
+		"priority":    opsgeniePriority(alert.Severity),
+	}
+}
+
+// opsgeniePriority maps an alert severity to an Opsgenie priority
+func opsgeniePriority(severity string) string {
+	switch severity {
+	case "critical":
+		return "P1"
+	case "warning":
+		return "P3"
+	}
+	return "P5"
+}
+
+func NewOpsgenieHandler(net hermes.Fetcher) Handler {
+	log := logger.GetInstance()
+
+	// Validated by the config service; EU accounts use a different host
+	hermes.RegisterService(opsgenieService, config.Env.OpsgenieApiUrl)
+
+	log.Info("Creating Opsgenie handler", map[string]interface{}{
+		"api_url": config.Env.OpsgenieApiUrl,
+	})
+
+	return &OpsgenieHandler{
+		net:    net,
+		apiKey: config.Env.OpsgenieApiKey,
+		logger: log,
+	}
+}
@@ -0,0 +1,153 @@
+package echo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// OutboxEntry is an alert waiting to be delivered to one handler
+type OutboxEntry struct {
+	ID         string    `json:"id"`                  // Sortable identifier, also the file name
+	Handler    string    `json:"handler"`             // Name of the queue the entry belongs to
+	Alert      *Alert    `json:"alert"`               // Alert to deliver
+	Attempts   int       `json:"attempts"`            // Failed delivery attempts so far
+	EnqueuedAt time.Time `json:"enqueuedAt"`          // When the alert was queued
+	LastError  string    `json:"lastError,omitempty"` // Error of the latest failed attempt
+	Group      string    `json:"group,omitempty"`     // ID of the first entry of a group delivered together, empty for a lone alert
+}
+
+// Outbox persists queued alerts as one JSON file per entry under
+// <dir>/<handler>/, so undelivered alerts survive a restart
+// Files are written to a temporary name and renamed, so a crash never leaves a partial entry
+type Outbox struct {
+	dir string
+}
+
+// OpenOutbox opens the outbox under dir, creating it if needed
+func OpenOutbox(dir string) (*Outbox, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Outbox{dir: dir}, nil
+}
+
+// outboxSeq breaks ties between entries created in the same nanosecond
+var outboxSeq atomic.Uint64
+
+// newOutboxEntry creates an entry for an alert with an identifier that sorts in queue order
+func newOutboxEntry(handler string, alert *Alert) *OutboxEntry {
+	now := time.Now()
+	return &OutboxEntry{
+		ID:         fmt.Sprintf("%019d-%06d", now.UnixNano(), outboxSeq.Add(1)%1000000),
+		Handler:    handler,
+		Alert:      alert,
+		EnqueuedAt: now,
+	}
+}
+
+// Save writes an entry, replacing an earlier version of it
+func (o *Outbox) Save(entry *OutboxEntry) error {
+	dir := filepath.Join(o.dir, entry.Handler)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, entry.ID+".json")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Remove deletes an entry; removing a missing entry is not an error
+func (o *Outbox) Remove(entry *OutboxEntry) error {
+	err := os.Remove(filepath.Join(o.dir, entry.Handler, entry.ID+".json"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// Load returns the entries saved for a handler, oldest first
+// Temporary files left by a crash are removed and unreadable entries are skipped
+// and reported through the returned error
+func (o *Outbox) Load(handler string) ([]*OutboxEntry, error) {
+	dir := filepath.Join(o.dir, handler)
+	files, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		entries []*OutboxEntry
+		broken  []string
+	)
+	for _, file := range files {
+		name := file.Name()
+		if strings.HasSuffix(name, ".tmp") {
+			os.Remove(filepath.Join(dir, name))
+			continue
+		}
+		if file.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			broken = append(broken, name)
+			continue
+		}
+		var entry OutboxEntry
+		if err := json.Unmarshal(data, &entry); err != nil || entry.Alert == nil {
+			broken = append(broken, name)
+			continue
+		}
+		entry.ID = strings.TrimSuffix(name, ".json")
+		entry.Handler = handler
+		entries = append(entries, &entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ID < entries[j].ID
+	})
+
+	if len(broken) > 0 {
+		return entries, fmt.Errorf("unreadable outbox entries: %s", strings.Join(broken, ", "))
+	}
+	return entries, nil
+}
+
+// Handlers lists the handler names that have saved entries
+func (o *Outbox) Handlers() ([]string, error) {
+	files, err := os.ReadDir(o.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, file := range files {
+		if !file.IsDir() {
+			continue
+		}
+		entries, err := os.ReadDir(filepath.Join(o.dir, file.Name()))
+		if err == nil && len(entries) > 0 {
+			names = append(names, file.Name())
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,131 @@
+package echo
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/LissaiDev/Delphos/internal/config"
+	"github.com/LissaiDev/Delphos/pkg/hermes"
+	"github.com/LissaiDev/Delphos/pkg/logger"
+)
+
+// pagerDutyService is the hermes service for the PagerDuty Events API
+const pagerDutyService = hermes.Service("PAGERDUTY")
+
+// pagerDutySummaryLimit is the longest summary PagerDuty accepts
+const pagerDutySummaryLimit = 1024
+
+// PagerDutyHandler sends alerts to the PagerDuty Events API v2
+// Every rule and label set maps to one incident through its dedup key, so
+// repeats update the open incident and resolved alerts close it
+type PagerDutyHandler struct {
+	net        hermes.Fetcher
+	routingKey string
+	logger     logger.BasicLogger
+}
+
+func (p *PagerDutyHandler) Handle(alert *Alert) error {
+	action := pagerDutyAction(alert.Status)
+
+	p.logger.Debug("PagerDuty handler processing alert", map[string]interface{}{
+		"rule":      alert.Rule,
+		"action":    action,
+		"dedup_key": alert.DedupKey(),
+	})
+
+	response := p.net.Post(pagerDutyService, "/v2/enqueue", p.BuildBody(alert), nil)
+	if !response.Success || response.Code >= 300 {
+		p.logger.Error("Failed to send PagerDuty event", map[string]interface{}{
+			"status_code": response.Code,
+			"response":    string(response.Data),
+			"rule":        alert.Rule,
+			"action":      action,
+		})
+		return fmt.Errorf("pagerduty event failed with status code: %d", response.Code)
+	}
+
+	p.logger.Info("PagerDuty event sent successfully", map[string]interface{}{
+		"rule":        alert.Rule,
+		"action":      action,
+		"dedup_key":   alert.DedupKey(),
+		"status_code": response.Code,
+	})
+
+	return nil
+}
+
+// BuildBody renders an alert as an Events API v2 event
+// Only trigger events carry a payload; acknowledge and resolve just name the dedup key
+func (p *PagerDutyHandler) BuildBody(alert *Alert) *map[string]any {
+	action := pagerDutyAction(alert.Status)
+	body := map[string]any{
+		"routing_key":  p.routingKey,
+		"event_action": action,
+		"dedup_key":    alert.DedupKey(),
+	}
+	if action != "trigger" {
+		return &body
+	}
+
+	summary := truncate(alert.Rule+": "+alert.Summary(), pagerDutySummaryLimit)
+
+	details := map[string]any{
+		"metric":      alert.Metric,
+		"labels":      alert.Labels,
+		"value":       alert.Value,
+		"threshold":   alert.Threshold,
+		"op":          alert.Op,
+		"peak":        alert.Peak,
+		"fingerprint": alert.Fingerprint,
+	}
+	if alert.Details != "" {
+		details["details"] = alert.Details
+	}
+
+	body["payload"] = map[string]any{
+		"summary":        summary,
+		"source":         orDash(alert.Host),
+		"severity":       pagerDutySeverity(alert.Severity),
+		"timestamp":      alert.StartsAt.Format(time.RFC3339),
+		"component":      alert.Metric,
+		"class":          alert.Rule,
+		"custom_details": details,
+	}
+	return &body
+}
+
+// pagerDutyAction maps an alert status to an event action
+func pagerDutyAction(status string) string {
+	switch status {
+	case StatusResolved:
+		return "resolve"
+	case StatusAcknowledged:
+		return "acknowledge"
+	}
+	return "trigger"
+}
+
+// pagerDutySeverity maps an alert severity to a PagerDuty severity
+func pagerDutySeverity(severity string) string {
+	switch severity {
+	case "critical":
+		return "critical"
+	case "warning":
+		return "warning"
+	}
+	return "info"
+}
+
+func NewPagerDutyHandler(net hermes.Fetcher) Handler {
+	log := logger.GetInstance()
+
+	log.Info("Creating PagerDuty handler", map[string]interface{}{
+		"configured": config.Env.PagerDutyRoutingKey != "",
+	})
+
+	return &PagerDutyHandler{
+		net:        net,
+		routingKey: config.Env.PagerDutyRoutingKey,
+		logger:     log,
+	}
+}
@@ -0,0 +1,416 @@
+package echo
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/LissaiDev/Delphos/internal/config"
+	"github.com/LissaiDev/Delphos/pkg/hermes"
+	"github.com/LissaiDev/Delphos/pkg/logger"
+)
+
+// Hermes services for push notification servers, registered with the configured base URLs
+const (
+	telegramService = hermes.Service("TELEGRAM")
+	ntfyService     = hermes.Service("NTFY")
+	gotifyService   = hermes.Service("GOTIFY")
+)
+
+// pushTitle is the one line heading shared by push notifications
+func pushTitle(alert *Alert) string {
+	title := fmt.Sprintf("%s: %s (%s)", strings.ToUpper(alert.Status), alert.Rule, alert.Severity)
+	if alert.Host != "" {
+		title += " on " + alert.Host
+	}
+	return title
+}
+
+// pushMessage is the Markdown body shared by ntfy and Gotify
+func pushMessage(alert *Alert) string {
+	message := alert.Summary()
+	if alert.Details != "" {
+		message += "\n\n" + alert.Details
+	}
+	return message
+}
+
+// pushGroupMessage is the Markdown body shared by ntfy and Gotify for several alerts
+// Details are left out to keep the message short
+func pushGroupMessage(alerts []*Alert) string {
+	parts := make([]string, len(alerts))
+	for i, alert := range alerts {
+		parts[i] = "**" + pushTitle(alert) + "**\n" + alert.Summary()
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// mostUrgent returns the alert of a group with the highest priority
+func mostUrgent(alerts []*Alert, priority func(*Alert) int) *Alert {
+	urgent := alerts[0]
+	for _, alert := range alerts[1:] {
+		if priority(alert) > priority(urgent) {
+			urgent = alert
+		}
+	}
+	return urgent
+}
+
+// postPush sends a push request and turns unsuccessful responses into errors
+func postPush(net hermes.Fetcher, log logger.BasicLogger, name string, service hermes.Service, path string, body *map[string]any, headers *map[string]string, alert *Alert) error {
+	response := net.Post(service, path, body, headers)
+	if !response.Success || response.Code >= 300 {
+		log.Error("Failed to send "+name+" notification", map[string]interface{}{
+			"status_code": response.Code,
+			"response":    string(response.Data),
+			"rule":        alert.Rule,
+		})
+		return fmt.Errorf("%s notification failed with status code: %d", strings.ToLower(name), response.Code)
+	}
+
+	log.Info(name+" notification sent successfully", map[string]interface{}{
+		"rule":        alert.Rule,
+		"status":      alert.Status,
+		"status_code": response.Code,
+	})
+	return nil
+}
+
+// TelegramHandler sends alerts to a chat through the Telegram Bot API
+type TelegramHandler struct {
+	net    hermes.Fetcher
+	token  string
+	chatID string
+	logger logger.BasicLogger
+}
+
+func (t *TelegramHandler) Handle(alert *Alert) error {
+	t.logger.Debug("Telegram handler processing alert", map[string]interface{}{
+		"rule":    alert.Rule,
+		"status":  alert.Status,
+		"chat_id": t.chatID,
+	})
+
+	return postPush(t.net, t.logger, "Telegram", telegramService, "/bot"+t.token+"/sendMessage", t.BuildBody(alert), nil, alert)
+}
+
+// telegramMaxText is the longest message text the Bot API accepts
+const telegramMaxText = 4096
+
+// HandleBatch sends the alerts under a common heading, in as few messages as the length limit allows
+func (t *TelegramHandler) HandleBatch(alerts []*Alert) error {
+	t.logger.Debug("Telegram handler processing alerts", map[string]interface{}{
+		"rule":    alerts[0].Rule,
+		"alerts":  len(alerts),
+		"chat_id": t.chatID,
+	})
+
+	var texts []string
+	text := "*" + telegramEscape(groupTitle(alerts)) + "*"
+	for _, alert := range alerts {
+		block := t.text(alert)
+		if len(text)+len("\n\n")+len(block) > telegramMaxText {
+			texts = append(texts, text)
+			text = block
+			continue
+		}
+		text += "\n\n" + block
+	}
+	texts = append(texts, text)
+
+	for _, text := range texts {
+		if err := postPush(t.net, t.logger, "Telegram", telegramService, "/bot"+t.token+"/sendMessage", t.body(text), nil, alerts[0]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BuildBody renders an alert as a MarkdownV2 message
+func (t *TelegramHandler) BuildBody(alert *Alert) *map[string]any {
+	return t.body(t.text(alert))
+}
+
+// body wraps MarkdownV2 text in a sendMessage request
+func (t *TelegramHandler) body(text string) *map[string]any {
+	return &map[string]any{
+		"chat_id":                  t.chatID,
+		"text":                     text,
+		"parse_mode":               "MarkdownV2",
+		"disable_web_page_preview": true,
+	}
+}
+
+// text renders an alert as MarkdownV2 text
+func (t *TelegramHandler) text(alert *Alert) string {
+	icon := "\U0001F6A8"
+	if alert.Resolved() {
+		icon = "✅"
+	} else if alert.Acknowledged() {
+		icon = "\U0001F440"
+	}
+
+	value := fmt.Sprintf("Value: %.1f (%s %.1f)", alert.Value, alert.Op, alert.Threshold)
+	if alert.Resolved() {
+		value = fmt.Sprintf("Value: %.1f, lasted %s (peak %.1f)", alert.Value, alert.Duration(), alert.Peak)
+	}
+
+	lines := []string{
+		icon + " *" + telegramEscape(pushTitle(alert)) + "*",
+		"",
+		"`" + telegramEscapeCode(alert.Series()) + "`",
+		telegramEscape(value),
+	}
+	if alert.Details != "" {
+		lines = append(lines, "", telegramEscape(alert.Details))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// telegramEscape escapes the characters MarkdownV2 reserves outside code spans
+func telegramEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune("_*[]()~`>#+-=|{}.!\\", r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// telegramEscapeCode escapes the characters MarkdownV2 reserves inside code spans
+func telegramEscapeCode(s string) string {
+	return strings.NewReplacer("\\", "\\\\", "`", "\\`").Replace(s)
+}
+
+func NewTelegramHandler(net hermes.Fetcher) Handler {
+	log := logger.GetInstance()
+
+	hermes.RegisterSensitiveService(telegramService, config.Env.TelegramApiUrl)
+
+	log.Info("Creating Telegram handler", map[string]interface{}{
+		"api_url": config.Env.TelegramApiUrl,
+		"chat_id": config.Env.TelegramChatID,
+	})
+
+	return &TelegramHandler{
+		net:    net,
+		token:  config.Env.TelegramBotToken,
+		chatID: config.Env.TelegramChatID,
+		logger: log,
+	}
+}
+
+// NtfyHandler publishes alerts to an ntfy topic
+type NtfyHandler struct {
+	net    hermes.Fetcher
+	topic  string
+	token  string
+	tags   []string
+	logger logger.BasicLogger
+}
+
+func (n *NtfyHandler) Handle(alert *Alert) error {
+	n.logger.Debug("ntfy handler processing alert", map[string]interface{}{
+		"rule":   alert.Rule,
+		"status": alert.Status,
+		"topic":  n.topic,
+	})
+
+	// JSON messages are published to the server root and name their topic
+	return postPush(n.net, n.logger, "ntfy", ntfyService, "/", n.BuildBody(alert), n.headers(), alert)
+}
+
+// HandleBatch publishes the alerts as one message with the priority of the most urgent
+func (n *NtfyHandler) HandleBatch(alerts []*Alert) error {
+	n.logger.Debug("ntfy handler processing alerts", map[string]interface{}{
+		"rule":   alerts[0].Rule,
+		"alerts": len(alerts),
+		"topic":  n.topic,
+	})
+
+	return postPush(n.net, n.logger, "ntfy", ntfyService, "/", n.BuildGroupBody(alerts), n.headers(), mostUrgent(alerts, ntfyPriority))
+}
+
+// headers returns the request headers, with the access token when one is set
+func (n *NtfyHandler) headers() *map[string]string {
+	headers := map[string]string{
+		"Content-Type": "application/json",
+	}
+	if n.token != "" {
+		headers["Authorization"] = "Bearer " + n.token
+	}
+	return &headers
+}
+
+// BuildBody renders an alert as an ntfy JSON message
+func (n *NtfyHandler) BuildBody(alert *Alert) *map[string]any {
+	tags := []string{ntfyIcon(alert), alert.Rule, alert.Severity}
+	tags = append(tags, n.tags...)
+
+	return &map[string]any{
+		"topic":    n.topic,
+		"title":    pushTitle(alert),
+		"message":  pushMessage(alert),
+		"priority": ntfyPriority(alert),
+		"tags":     tags,
+		"markdown": true,
+	}
+}
+
+// BuildGroupBody renders several alerts as one ntfy JSON message
+// The icon and priority are those of the most urgent alert
+func (n *NtfyHandler) BuildGroupBody(alerts []*Alert) *map[string]any {
+	urgent := mostUrgent(alerts, ntfyPriority)
+	tags := []string{ntfyIcon(urgent)}
+	for _, alert := range alerts {
+		if !slices.Contains(tags, alert.Rule) {
+			tags = append(tags, alert.Rule)
+		}
+	}
+	tags = append(tags, n.tags...)
+
+	return &map[string]any{
+		"topic":    n.topic,
+		"title":    groupTitle(alerts),
+		"message":  pushGroupMessage(alerts),
+		"priority": ntfyPriority(urgent),
+		"tags":     tags,
+		"markdown": true,
+	}
+}
+
+// ntfyIcon returns the tag ntfy clients show as the icon of an alert
+// Tags naming an emoji are shown as icons
+func ntfyIcon(alert *Alert) string {
+	if alert.Resolved() {
+		return "white_check_mark"
+	} else if alert.Acknowledged() {
+		return "eyes"
+	}
+	return "rotating_light"
+}
+
+// ntfyPriority maps an alert to an ntfy priority (1 min to 5 max)
+func ntfyPriority(alert *Alert) int {
+	if alert.Resolved() || alert.Acknowledged() {
+		return 2
+	}
+	switch alert.Severity {
+	case "critical":
+		return 5
+	case "warning":
+		return 4
+	}
+	return 3
+}
+
+func NewNtfyHandler(net hermes.Fetcher) Handler {
+	log := logger.GetInstance()
+
+	hermes.RegisterService(ntfyService, config.Env.NtfyUrl)
+
+	log.Info("Creating ntfy handler", map[string]interface{}{
+		"url":   config.Env.NtfyUrl,
+		"topic": config.Env.NtfyTopic,
+		"auth":  config.Env.NtfyToken != "",
+	})
+
+	return &NtfyHandler{
+		net:    net,
+		topic:  config.Env.NtfyTopic,
+		token:  config.Env.NtfyToken,
+		tags:   config.Env.NtfyTags,
+		logger: log,
+	}
+}
+
+// GotifyHandler pushes alerts to a Gotify application
+type GotifyHandler struct {
+	net    hermes.Fetcher
+	token  string
+	logger logger.BasicLogger
+}
+
+func (g *GotifyHandler) Handle(alert *Alert) error {
+	g.logger.Debug("Gotify handler processing alert", map[string]interface{}{
+		"rule":   alert.Rule,
+		"status": alert.Status,
+	})
+
+	return postPush(g.net, g.logger, "Gotify", gotifyService, "/message", g.BuildBody(alert), g.headers(), alert)
+}
+
+// HandleBatch pushes the alerts as one message with the priority of the most urgent
+func (g *GotifyHandler) HandleBatch(alerts []*Alert) error {
+	g.logger.Debug("Gotify handler processing alerts", map[string]interface{}{
+		"rule":   alerts[0].Rule,
+		"alerts": len(alerts),
+	})
+
+	return postPush(g.net, g.logger, "Gotify", gotifyService, "/message", g.BuildGroupBody(alerts), g.headers(), mostUrgent(alerts, gotifyPriority))
+}
+
+// headers returns the request headers carrying the application token
+func (g *GotifyHandler) headers() *map[string]string {
+	return &map[string]string{
+		"Content-Type": "application/json",
+		"X-Gotify-Key": g.token,
+	}
+}
+
+// BuildBody renders an alert as a Gotify message with a Markdown body
+func (g *GotifyHandler) BuildBody(alert *Alert) *map[string]any {
+	return &map[string]any{
+		"title":    pushTitle(alert),
+		"message":  pushMessage(alert),
+		"priority": gotifyPriority(alert),
+		"extras": map[string]any{
+			"client::display": map[string]any{"contentType": "text/markdown"},
+		},
+	}
+}
+
+// BuildGroupBody renders several alerts as one Gotify message
+func (g *GotifyHandler) BuildGroupBody(alerts []*Alert) *map[string]any {
+	return &map[string]any{
+		"title":    groupTitle(alerts),
+		"message":  pushGroupMessage(alerts),
+		"priority": gotifyPriority(mostUrgent(alerts, gotifyPriority)),
+		"extras": map[string]any{
+			"client::display": map[string]any{"contentType": "text/markdown"},
+		},
+	}
+}
+
+// gotifyPriority maps an alert to a Gotify priority (0 to 10, 8 and up interrupt on Android)
+func gotifyPriority(alert *Alert) int {
+	if alert.Resolved() || alert.Acknowledged() {
+		return 2
+	}
+	switch alert.Severity {
+	case "critical":
+		return 8
+	case "warning":
+		return 5
+	}
+	return 3
+}
+
+func NewGotifyHandler(net hermes.Fetcher) Handler {
+	log := logger.GetInstance()
+
+	hermes.RegisterService(gotifyService, config.Env.GotifyUrl)
+
+	log.Info("Creating Gotify handler", map[string]interface{}{
+		"url": config.Env.GotifyUrl,
+	})
+
+	return &GotifyHandler{
+		net:    net,
+		token:  config.Env.GotifyToken,
+		logger: log,
+	}
+}
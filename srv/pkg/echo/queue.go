@@ -0,0 +1,360 @@
+package echo
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/LissaiDev/Delphos/pkg/logger"
+)
+
+// queueMaxBackoff caps the wait between delivery attempts
+const queueMaxBackoff = 10 * time.Minute
+
+// QueueOptions configures the delivery queues of an Echo
+type QueueOptions struct {
+	Workers     int           // Worker goroutines per handler; more than one may reorder deliveries
+	Size        int           // Entries kept per handler before the oldest is dropped
+	MaxAttempts int           // Delivery attempts before an entry is dropped
+	Backoff     time.Duration // Wait after the first failed attempt, doubled on every further failure
+}
+
+// QueueStats reports the state of a handler queue
+type QueueStats struct {
+	Handler        string    `json:"handler"`                 // Queue name, derived from the handler type
+	Depth          int       `json:"depth"`                   // Entries waiting or being delivered
+	InFlight       int       `json:"inFlight"`                // Entries being delivered
+	Delivered      uint64    `json:"delivered"`               // Successful deliveries since startup
+	Failures       uint64    `json:"failures"`                // Failed delivery attempts since startup
+	Dropped        uint64    `json:"dropped"`                 // Entries given up on or evicted from a full queue
+	LastError      string    `json:"lastError,omitempty"`     // Error of the latest failed attempt
+	LastFailureAt  time.Time `json:"lastFailureAt,omitzero"`  // Time of the latest failed attempt
+	LastDeliveryAt time.Time `json:"lastDeliveryAt,omitzero"` // Time of the latest successful delivery
+}
+
+// Queue delivers alerts to a single handler from worker goroutines
+// A failing entry is retried with exponential backoff before the worker moves on,
+// so a handler sees the alerts of a series in order. Entries are saved to the
+// outbox until they are delivered or dropped. A BatchHandler gets the entries
+// of a group pushed by PushGroup in one call. For a WindowedHandler with a batch
+// window, a single worker waits out the window of the oldest entry and delivers
+// everything waiting by then in one call.
+type Queue struct {
+	name    string
+	handler Handler
+	batcher BatchHandler  // Set when handler can deliver several alerts in one call
+	window  time.Duration // Batch window of a WindowedHandler, 0 when entries are not collected over time
+	outbox  *Outbox       // nil keeps entries in memory only
+	options QueueOptions
+	pending []*OutboxEntry
+	stats   QueueStats
+	wake    chan struct{}
+	mu      sync.Mutex
+	logger  logger.BasicLogger
+}
+
+// NewQueue creates a queue for handler and loads the entries it left in the outbox
+// Workers are started by Start
+func NewQueue(name string, handler Handler, outbox *Outbox, options QueueOptions) *Queue {
+	q := &Queue{
+		name:    name,
+		handler: handler,
+		outbox:  outbox,
+		options: options,
+		stats:   QueueStats{Handler: name},
+		wake:    make(chan struct{}, 1),
+		logger:  logger.GetInstance(),
+	}
+	q.batcher, _ = handler.(BatchHandler)
+	if windowed, ok := handler.(WindowedHandler); ok {
+		q.window = max(windowed.BatchWindow(), 0)
+	}
+
+	if outbox != nil {
+		entries, err := outbox.Load(name)
+		if err != nil {
+			q.logger.Error("Failed to load queued notifications", map[string]interface{}{
+				"handler": name,
+				"error":   err.Error(),
+			})
+		}
+		if len(entries) > 0 {
+			q.pending = entries
+			q.logger.Info("Recovered queued notifications", map[string]interface{}{
+				"handler": name,
+				"entries": len(entries),
+			})
+		}
+	}
+
+	return q
+}
+
+// Start launches the worker goroutines
+// A batching queue runs a single worker, since batches are sent one at a time
+func (q *Queue) Start() {
+	workers := q.options.Workers
+	if workers < 1 || q.window > 0 {
+		workers = 1
+	}
+	for i := 0; i < workers; i++ {
+		go q.work()
+	}
+	q.signal()
+}
+
+// Push queues an alert for delivery
+// When the queue is full the oldest waiting entry is dropped. A failure to save
+// the entry is returned, but the alert is still delivered from memory.
+func (q *Queue) Push(alert *Alert) error {
+	return q.push([]*OutboxEntry{newOutboxEntry(q.name, alert)})
+}
+
+// PushGroup queues alerts to be delivered together
+// A BatchHandler receives them in one call, other handlers one by one.
+func (q *Queue) PushGroup(alerts []*Alert) error {
+	entries := make([]*OutboxEntry, len(alerts))
+	for i, alert := range alerts {
+		entries[i] = newOutboxEntry(q.name, alert)
+		entries[i].Group = entries[0].ID
+	}
+	return q.push(entries)
+}
+
+// push saves and queues entries in order
+func (q *Queue) push(entries []*OutboxEntry) error {
+	var errs []error
+	if q.outbox != nil {
+		for _, entry := range entries {
+			if err := q.outbox.Save(entry); err != nil {
+				q.logger.Error("Failed to save notification to the outbox", map[string]interface{}{
+					"handler": q.name,
+					"rule":    entry.Alert.Rule,
+					"error":   err.Error(),
+				})
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	q.mu.Lock()
+	for _, entry := range entries {
+		if q.options.Size > 0 && len(q.pending) >= q.options.Size {
+			evicted := q.pending[0]
+			q.pending = q.pending[1:]
+			q.stats.Dropped++
+			q.forget(evicted)
+			q.logger.Warn("Notification queue full, dropping oldest entry", map[string]interface{}{
+				"handler": q.name,
+				"rule":    evicted.Alert.Rule,
+				"size":    q.options.Size,
+			})
+		}
+		q.pending = append(q.pending, entry)
+	}
+	depth := len(q.pending) + q.stats.InFlight
+	q.mu.Unlock()
+
+	q.logger.Debug("Notification queued", map[string]interface{}{
+		"handler": q.name,
+		"rule":    entries[0].Alert.Rule,
+		"status":  entries[0].Alert.Status,
+		"alerts":  len(entries),
+		"depth":   depth,
+	})
+
+	q.signal()
+	return errors.Join(errs...)
+}
+
+// Stats returns a snapshot of the queue state
+func (q *Queue) Stats() QueueStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	stats := q.stats
+	stats.Depth = len(q.pending) + q.stats.InFlight
+	return stats
+}
+
+// signal wakes a waiting worker without blocking
+func (q *Queue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// work delivers entries until the process exits
+func (q *Queue) work() {
+	for {
+		batch := q.next()
+		if batch == nil {
+			<-q.wake
+			continue
+		}
+		q.deliver(batch)
+
+		q.mu.Lock()
+		q.stats.InFlight -= len(batch)
+		q.mu.Unlock()
+	}
+}
+
+// next takes the entries to deliver together, or returns nil when there are none
+// That is the oldest waiting entry with the rest of its group, or for a queue
+// with a batch window every entry waiting once the window of the oldest has passed
+func (q *Queue) next() []*OutboxEntry {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.pending) == 0 {
+		return nil
+	}
+
+	if q.window > 0 {
+		// Entries stay pending, and in the outbox, while the window is open
+		if wait := time.Until(q.pending[0].EnqueuedAt.Add(q.window)); wait > 0 {
+			q.mu.Unlock()
+			time.Sleep(wait)
+			q.mu.Lock()
+		}
+		batch := q.pending
+		q.pending = nil
+		q.stats.InFlight += len(batch)
+		return batch
+	}
+
+	n := 1
+	if group := q.pending[0].Group; group != "" && q.batcher != nil {
+		for n < len(q.pending) && q.pending[n].Group == group {
+			n++
+		}
+	}
+	batch := q.pending[:n:n]
+	q.pending = q.pending[n:]
+	q.stats.InFlight += n
+
+	// Let another worker pick up the rest
+	if len(q.pending) > 0 {
+		q.signal()
+	}
+	return batch
+}
+
+// deliver hands entries to the handler, retrying until they succeed or run out of attempts
+func (q *Queue) deliver(batch []*OutboxEntry) {
+	for len(batch) > 0 {
+		err := q.handle(batch)
+		now := time.Now()
+
+		if err == nil {
+			q.mu.Lock()
+			q.stats.Delivered += uint64(len(batch))
+			q.stats.LastDeliveryAt = now
+			for _, entry := range batch {
+				q.forget(entry)
+			}
+			q.mu.Unlock()
+
+			q.logger.Debug("Notification delivered", map[string]interface{}{
+				"handler":  q.name,
+				"rule":     batch[0].Alert.Rule,
+				"alerts":   len(batch),
+				"attempts": batch[0].Attempts + 1,
+				"latency":  now.Sub(batch[0].EnqueuedAt).String(),
+			})
+			return
+		}
+
+		q.mu.Lock()
+		q.stats.Failures++
+		q.stats.LastError = err.Error()
+		q.stats.LastFailureAt = now
+		q.mu.Unlock()
+
+		retry := batch[:0]
+		for _, entry := range batch {
+			entry.Attempts++
+			entry.LastError = err.Error()
+
+			if entry.Attempts >= q.options.MaxAttempts {
+				q.mu.Lock()
+				q.stats.Dropped++
+				q.forget(entry)
+				q.mu.Unlock()
+
+				q.logger.Error("Giving up on notification", map[string]interface{}{
+					"handler":  q.name,
+					"rule":     entry.Alert.Rule,
+					"status":   entry.Alert.Status,
+					"attempts": entry.Attempts,
+					"error":    err.Error(),
+				})
+				continue
+			}
+
+			// Keep the attempt count across restarts
+			if q.outbox != nil {
+				if err := q.outbox.Save(entry); err != nil {
+					q.logger.Error("Failed to update notification in the outbox", map[string]interface{}{
+						"handler": q.name,
+						"error":   err.Error(),
+					})
+				}
+			}
+			retry = append(retry, entry)
+		}
+		batch = retry
+		if len(batch) == 0 {
+			return
+		}
+
+		backoff := q.backoff(batch[0].Attempts)
+		q.logger.Warn("Notification delivery failed, retrying", map[string]interface{}{
+			"handler":  q.name,
+			"rule":     batch[0].Alert.Rule,
+			"alerts":   len(batch),
+			"attempts": batch[0].Attempts,
+			"retry_in": backoff.String(),
+			"error":    err.Error(),
+		})
+		time.Sleep(backoff)
+	}
+}
+
+// handle calls the handler once for the entries
+func (q *Queue) handle(batch []*OutboxEntry) error {
+	if len(batch) == 1 {
+		return q.handler.Handle(batch[0].Alert)
+	}
+
+	alerts := make([]*Alert, len(batch))
+	for i, entry := range batch {
+		alerts[i] = entry.Alert
+	}
+	return q.batcher.HandleBatch(alerts)
+}
+
+// backoff returns the wait after the given number of failed attempts
+func (q *Queue) backoff(attempts int) time.Duration {
+	wait := q.options.Backoff
+	for i := 1; i < attempts && wait < queueMaxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, queueMaxBackoff)
+}
+
+// forget removes a finished entry from the outbox; the caller holds q.mu
+func (q *Queue) forget(entry *OutboxEntry) {
+	if q.outbox == nil {
+		return
+	}
+	if err := q.outbox.Remove(entry); err != nil {
+		q.logger.Error("Failed to remove notification from the outbox", map[string]interface{}{
+			"handler": q.name,
+			"id":      entry.ID,
+			"error":   err.Error(),
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package echo
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers carrying the signature of webhook requests
+const (
+	WebhookSignatureHeader = "X-Delphos-Signature" // "sha256=" followed by the hex HMAC
+	WebhookTimestampHeader = "X-Delphos-Timestamp" // Unix seconds when the request was signed
+)
+
+// DefaultWebhookTolerance is how far a signed timestamp may be from the receiver's clock
+const DefaultWebhookTolerance = 5 * time.Minute
+
+// Signature verification errors
+var (
+	ErrMissingSignature = errors.New("missing webhook signature")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrStaleTimestamp   = errors.New("webhook timestamp outside tolerance")
+)
+
+// SignWebhook computes the signature header value for a body sent at timestamp
+// The HMAC-SHA256 covers "<timestamp>.<body>", so a captured request cannot be
+// replayed with a fresh timestamp
+func SignWebhook(secret []byte, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte{'.'})
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhook checks a signature and rejects timestamps further than
+// tolerance from now (DefaultWebhookTolerance when tolerance is not positive)
+func VerifyWebhook(secret []byte, timestamp, signature string, body []byte, tolerance time.Duration, now time.Time) error {
+	if timestamp == "" || signature == "" {
+		return ErrMissingSignature
+	}
+	if tolerance <= 0 {
+		tolerance = DefaultWebhookTolerance
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if skew := now.Sub(time.Unix(ts, 0)); skew > tolerance || skew < -tolerance {
+		return ErrStaleTimestamp
+	}
+
+	expected := SignWebhook(secret, ts, body)
+	if !strings.HasPrefix(signature, "sha256=") || !hmac.Equal([]byte(signature), []byte(expected)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// VerifyWebhookRequest verifies an incoming webhook request and returns its body
+// The request body is replaced so handlers can still read it
+func VerifyWebhookRequest(r *http.Request, secret []byte, tolerance time.Duration) ([]byte, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = VerifyWebhook(secret, r.Header.Get(WebhookTimestampHeader), r.Header.Get(WebhookSignatureHeader), body, tolerance, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return body, nil
+}
@@ -0,0 +1,188 @@
+package echo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/LissaiDev/Delphos/internal/config"
+	"github.com/LissaiDev/Delphos/pkg/hermes"
+	"github.com/LissaiDev/Delphos/pkg/logger"
+)
+
+// slackService is the hermes service for Slack incoming webhooks
+const slackService = hermes.Service("SLACK")
+
+// SlackHandler posts alerts to a Slack incoming webhook as Block Kit messages
+type SlackHandler struct {
+	net    hermes.Fetcher
+	url    string // Webhook path relative to the Slack service base URL
+	logger logger.BasicLogger
+}
+
+func (s *SlackHandler) Handle(alert *Alert) error {
+	s.logger.Debug("Slack handler processing alert", map[string]interface{}{
+		"rule":   alert.Rule,
+		"status": alert.Status,
+	})
+
+	if s.url == "" {
+		s.logger.Warn("Slack webhook URL not configured, skipping notification", map[string]interface{}{
+			"message": alert.Text(),
+		})
+		return nil
+	}
+
+	return s.send(s.BuildBody(alert), alert.Rule, alert.Status, alert.Text())
+}
+
+// slackGroupAttachments is the number of alerts sent per message by HandleBatch
+// Slack advises against more than 20 attachments per message
+const slackGroupAttachments = 20
+
+// HandleBatch sends the alerts as the attachments of as few messages as Slack allows
+func (s *SlackHandler) HandleBatch(alerts []*Alert) error {
+	if s.url == "" {
+		s.logger.Warn("Slack webhook URL not configured, skipping notification", map[string]interface{}{
+			"message": groupTitle(alerts),
+		})
+		return nil
+	}
+
+	for start := 0; start < len(alerts); start += slackGroupAttachments {
+		chunk := alerts[start:min(start+slackGroupAttachments, len(alerts))]
+		attachments := make([]map[string]any, len(chunk))
+		for i, alert := range chunk {
+			attachments[i] = s.attachment(alert)
+		}
+
+		body := &map[string]any{
+			"text":        groupTitle(alerts),
+			"attachments": attachments,
+		}
+		if err := s.send(body, chunk[0].Rule, chunk[0].Status, groupTitle(chunk)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// send posts a message body to the webhook; rule, status and message describe it in logs
+func (s *SlackHandler) send(body *map[string]any, rule, status, message string) error {
+	response := s.net.Post(slackService, s.url, body, nil)
+	if !response.Success || response.Code >= 300 {
+		s.logger.Error("Failed to send Slack webhook", map[string]interface{}{
+			"status_code": response.Code,
+			"response":    string(response.Data),
+			"message":     message,
+		})
+		return fmt.Errorf("slack webhook failed with status code: %d", response.Code)
+	}
+
+	s.logger.Info("Slack webhook sent successfully", map[string]interface{}{
+		"rule":        rule,
+		"status":      status,
+		"status_code": response.Code,
+	})
+
+	return nil
+}
+
+// BuildBody renders an alert as Block Kit blocks inside an attachment
+// The attachment carries the severity color, which blocks alone cannot
+func (s *SlackHandler) BuildBody(alert *Alert) *map[string]any {
+	return &map[string]any{
+		"text":        alert.Text(),
+		"attachments": []map[string]any{s.attachment(alert)},
+	}
+}
+
+// attachment renders the colored attachment of an alert
+func (s *SlackHandler) attachment(alert *Alert) map[string]any {
+	title := ":rotating_light: FIRING: " + alert.Rule
+	if alert.Resolved() {
+		title = ":white_check_mark: RESOLVED: " + alert.Rule
+	} else if alert.Acknowledged() {
+		title = ":eyes: ACKNOWLEDGED: " + alert.Rule
+	}
+
+	fields := []map[string]any{
+		slackField("Severity", alert.Severity),
+		slackField("Host", orDash(alert.Host)),
+		slackField("Metric", "`"+alert.Series()+"`"),
+		slackField("Value", fmt.Sprintf("%.1f", alert.Value)),
+	}
+	if alert.Resolved() {
+		fields = append(fields,
+			slackField("Peak", fmt.Sprintf("%.1f", alert.Peak)),
+			slackField("Duration", alert.Duration().String()),
+		)
+	} else {
+		fields = append(fields, slackField("Threshold", fmt.Sprintf("%s %.1f", alert.Op, alert.Threshold)))
+	}
+
+	blocks := []map[string]any{
+		{
+			"type": "header",
+			"text": map[string]any{"type": "plain_text", "text": title, "emoji": true},
+		},
+		{
+			"type":   "section",
+			"fields": fields,
+		},
+	}
+	if alert.Details != "" {
+		blocks = append(blocks, map[string]any{
+			"type": "section",
+			"text": map[string]any{"type": "mrkdwn", "text": slackEscape(alert.Details)},
+		})
+	}
+
+	at := alert.StartsAt
+	if alert.Resolved() {
+		at = alert.EndsAt
+	}
+	blocks = append(blocks, map[string]any{
+		"type": "context",
+		"elements": []map[string]any{
+			{
+				"type": "mrkdwn",
+				"text": fmt.Sprintf("<!date^%d^{date_short_pretty} {time_secs}|%s> · %s", at.Unix(), at.UTC().Format("2006-01-02 15:04:05 UTC"), alert.Fingerprint),
+			},
+		},
+	})
+
+	return map[string]any{
+		"color":  fmt.Sprintf("#%06X", alert.Color()),
+		"blocks": blocks,
+	}
+}
+
+// slackField renders a labelled mrkdwn field of a section block
+func slackField(name, value string) map[string]any {
+	return map[string]any{
+		"type": "mrkdwn",
+		"text": "*" + name + "*\n" + slackEscape(value),
+	}
+}
+
+// slackEscape escapes the characters mrkdwn treats as control sequences
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+func NewSlackHandler(net hermes.Fetcher) Handler {
+	log := logger.GetInstance()
+
+	// Accept the full webhook URL as well as its path
+	url := strings.TrimPrefix(config.Env.SlackWebhookUrl, hermes.SERVICES[slackService])
+
+	log.Info("Creating Slack handler", map[string]interface{}{
+		"configured": url != "",
+	})
+
+	return &SlackHandler{
+		net:    net,
+		url:    url,
+		logger: log,
+	}
+}
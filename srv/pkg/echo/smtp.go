@@ -0,0 +1,154 @@
+package echo
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// SMTP transport security modes
+const (
+	SMTPStartTLS = "starttls" // Plain connection upgraded with STARTTLS
+	SMTPTLS      = "tls"      // TLS from the first byte (usually port 465)
+	SMTPNone     = "none"     // No encryption, for local relays and test servers
+)
+
+// SMTP authentication mechanisms
+const (
+	SMTPAuthPlain = "plain"
+	SMTPAuthLogin = "login"
+)
+
+// smtpTimeout bounds a whole SMTP conversation
+const smtpTimeout = 30 * time.Second
+
+// MailTransport delivers a fully formed message to bare envelope addresses
+// SMTPTransport is the production implementation; a stand-in can capture messages instead
+type MailTransport interface {
+	Send(from string, to []string, message []byte) error
+}
+
+// SMTPTransport sends mail through an SMTP server
+type SMTPTransport struct {
+	Host     string
+	Port     int
+	Username string // Empty skips authentication
+	Password string
+	Auth     string // SMTPAuthPlain or SMTPAuthLogin
+	TLS      string // SMTPStartTLS, SMTPTLS or SMTPNone
+}
+
+// Send delivers a message to every recipient in a single SMTP transaction
+func (t *SMTPTransport) Send(from string, to []string, message []byte) error {
+	addr := net.JoinHostPort(t.Host, strconv.Itoa(t.Port))
+	dialer := &net.Dialer{Timeout: smtpTimeout}
+	tlsConfig := &tls.Config{ServerName: t.Host}
+
+	var (
+		conn net.Conn
+		err  error
+	)
+	if t.TLS == SMTPTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(time.Now().Add(smtpTimeout))
+
+	client, err := smtp.NewClient(conn, t.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if t.TLS == SMTPStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errors.New("smtp server does not support STARTTLS")
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return err
+		}
+	}
+
+	if t.Username != "" {
+		if err := client.Auth(t.auth()); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := client.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("recipient %s: %w", rcpt, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(message); err != nil {
+		w.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// auth returns the configured authentication mechanism
+func (t *SMTPTransport) auth() smtp.Auth {
+	if t.Auth == SMTPAuthLogin {
+		return &loginAuth{username: t.Username, password: t.Password, host: t.Host}
+	}
+	return smtp.PlainAuth("", t.Username, t.Password, t.Host)
+}
+
+// loginAuth implements the LOGIN mechanism, which net/smtp does not provide
+// Like smtp.PlainAuth it refuses to send credentials over an unencrypted
+// connection unless the server is on localhost
+type loginAuth struct {
+	username string
+	password string
+	host     string
+}
+
+func (a *loginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if !server.TLS && !isLocalhost(server.Name) {
+		return "", nil, errors.New("unencrypted connection")
+	}
+	if server.Name != a.host {
+		return "", nil, errors.New("wrong host name")
+	}
+	return "LOGIN", nil, nil
+}
+
+func (a *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+	switch string(fromServer) {
+	case "Username:", "User Name\x00":
+		return []byte(a.username), nil
+	case "Password:", "Password\x00":
+		return []byte(a.password), nil
+	}
+	return nil, fmt.Errorf("unexpected LOGIN challenge %q", fromServer)
+}
+
+// isLocalhost reports whether a server name refers to the local machine
+func isLocalhost(name string) bool {
+	return name == "localhost" || name == "127.0.0.1" || name == "::1"
+}
@@ -0,0 +1,182 @@
+package echo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/LissaiDev/Delphos/internal/config"
+	"github.com/LissaiDev/Delphos/pkg/hermes"
+	"github.com/LissaiDev/Delphos/pkg/logger"
+)
+
+// webhookService is the hermes service registered for the configured webhook host
+const webhookService = hermes.Service("WEBHOOK")
+
+// WebhookPayloadVersion is the version of the default webhook payload layout
+const WebhookPayloadVersion = 1
+
+// ErrInvalidWebhookBody is returned when a body template does not render valid JSON
+var ErrInvalidWebhookBody = errors.New("webhook body template did not render valid JSON")
+
+// WebhookPayload is the default JSON body of generic webhook requests
+// It is also the data passed to user-defined body templates
+type WebhookPayload struct {
+	Version int    `json:"version"` // Payload layout version
+	Text    string `json:"text"`    // Plain text rendering of the alert
+	Alert   *Alert `json:"alert"`   // The alert itself
+}
+
+// WebhookHandler posts alerts as JSON to an arbitrary URL
+// Requests are signed with HMAC-SHA256 when a secret is configured (see VerifyWebhook)
+type WebhookHandler struct {
+	net      hermes.Fetcher
+	path     string // Path and query relative to the registered service base URL
+	secret   []byte
+	headers  map[string]string
+	template *template.Template // Optional body template; nil sends WebhookPayload
+	logger   logger.BasicLogger
+}
+
+func (w *WebhookHandler) Handle(alert *Alert) error {
+	w.logger.Debug("Webhook handler processing alert", map[string]interface{}{
+		"rule":   alert.Rule,
+		"status": alert.Status,
+	})
+
+	body, err := w.BuildBody(alert)
+	if err != nil {
+		w.logger.Error("Failed to render webhook body", map[string]interface{}{
+			"rule":  alert.Rule,
+			"error": err.Error(),
+		})
+		return err
+	}
+
+	headers := map[string]string{
+		"Content-Type": "application/json",
+	}
+	for name, value := range w.headers {
+		headers[name] = value
+	}
+	if len(w.secret) > 0 {
+		timestamp := time.Now().Unix()
+		headers[WebhookTimestampHeader] = strconv.FormatInt(timestamp, 10)
+		headers[WebhookSignatureHeader] = SignWebhook(w.secret, timestamp, body)
+	}
+
+	response := w.net.Fetch(&hermes.Request{
+		Service: webhookService,
+		Url:     w.path,
+		Method:  hermes.MethodPost,
+		Headers: &headers,
+		RawBody: body,
+	})
+	if !response.Success || response.Code >= 300 {
+		w.logger.Error("Failed to send alert webhook", map[string]interface{}{
+			"status_code": response.Code,
+			"rule":        alert.Rule,
+		})
+		return fmt.Errorf("alert webhook failed with status code: %d", response.Code)
+	}
+
+	w.logger.Info("Alert webhook sent successfully", map[string]interface{}{
+		"rule":        alert.Rule,
+		"status":      alert.Status,
+		"status_code": response.Code,
+		"signed":      len(w.secret) > 0,
+	})
+
+	return nil
+}
+
+// BuildBody renders the request body, through the template when one is configured
+func (w *WebhookHandler) BuildBody(alert *Alert) ([]byte, error) {
+	payload := &WebhookPayload{
+		Version: WebhookPayloadVersion,
+		Text:    alert.Text(),
+		Alert:   alert,
+	}
+
+	if w.template == nil {
+		return json.Marshal(payload)
+	}
+
+	var buf bytes.Buffer
+	if err := w.template.Execute(&buf, payload); err != nil {
+		return nil, err
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, ErrInvalidWebhookBody
+	}
+	return buf.Bytes(), nil
+}
+
+// webhookTemplateFuncs are available to body templates
+var webhookTemplateFuncs = template.FuncMap{
+	// json renders a value as JSON, e.g. {{ json .Alert.Labels }} or "text": {{ json .Text }}
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+func NewWebhookHandler(net hermes.Fetcher) Handler {
+	log := logger.GetInstance()
+	cfg := config.Env
+
+	handler := &WebhookHandler{
+		net:     net,
+		secret:  []byte(cfg.AlertWebhookSecret),
+		headers: cfg.AlertWebhookHeaders,
+		logger:  log,
+	}
+
+	// Validated by the config service
+	target, _ := url.Parse(cfg.AlertWebhookUrl)
+	hermes.RegisterService(webhookService, target.Scheme+"://"+target.Host)
+	handler.path = target.EscapedPath()
+	if handler.path == "" {
+		// hermes rejects an empty path, so a bare host posts to its root
+		handler.path = "/"
+	}
+	if target.RawQuery != "" {
+		handler.path += "?" + target.RawQuery
+	}
+
+	if cfg.AlertWebhookTemplate != "" {
+		tmpl, err := loadWebhookTemplate(cfg.AlertWebhookTemplate)
+		if err != nil {
+			log.Error("Failed to load webhook body template, using the default payload", map[string]interface{}{
+				"path":  cfg.AlertWebhookTemplate,
+				"error": err.Error(),
+			})
+		} else {
+			handler.template = tmpl
+		}
+	}
+
+	log.Info("Creating webhook handler", map[string]interface{}{
+		"host":     target.Host,
+		"signed":   len(handler.secret) > 0,
+		"headers":  len(handler.headers),
+		"template": handler.template != nil,
+	})
+
+	return handler
+}
+
+// loadWebhookTemplate reads and parses a body template file
+func loadWebhookTemplate(path string) (*template.Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return template.New("webhook").Funcs(webhookTemplateFuncs).Option("missingkey=error").Parse(string(data))
+}
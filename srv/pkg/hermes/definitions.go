@@ -17,9 +17,22 @@ const (
 )
 
 var SERVICES = map[Service]string{
-	Service("DISCORD"): "https://discord.com/api/webhooks",
+	Service("DISCORD"):   "https://discord.com/api/webhooks",
+	Service("SLACK"):     "https://hooks.slack.com/services",
+	Service("PAGERDUTY"): "https://events.pagerduty.com",
+	Service("OPSGENIE"):  "https://api.opsgenie.com",
 }
 
+// sensitiveServices lists services whose request paths carry credentials,
+// such as webhook tokens, and are never logged
+var sensitiveServices = map[Service]bool{
+	Service("DISCORD"): true,
+	Service("SLACK"):   true,
+}
+
+// redactedValue stands in for credentials in logs
+const redactedValue = "[REDACTED]"
+
 type Response struct {
 	Code    int    `json:"code"`
 	Success bool   `json:"success"`
@@ -32,7 +45,9 @@ type Request struct {
 	Method       Method             `json:"method"`
 	Headers      *map[string]string `json:"headers"`
 	Body         *map[string]any    `json:"body"`
+	RawBody      []byte             `json:"-"` // Pre-encoded body sent as is, takes precedence over Body
 	ResolvedBody io.Reader
+	payload      []byte // Encoded body, re-read on every attempt
 }
 
 type Fetcher interface {
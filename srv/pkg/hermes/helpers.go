@@ -79,19 +79,24 @@ func (req *Request) Sanitize() (err error) {
 	}
 
 	// Process request body
-	if req.Body != nil {
+	if req.Body != nil || req.RawBody != nil {
 		// Validate method for request with body
 		if req.Method.String() == "GET" || req.Method.String() == "DELETE" {
 			return errors.New("INVALID METHOD: " + req.Method.String() + " cannot have a request body")
 		}
 
-		// Marshal body to JSON
-		resolved, err := json.Marshal(req.Body)
-		if err != nil {
-			return errors.New("INVALID BODY: failed to marshal JSON: " + err.Error())
+		if req.RawBody != nil {
+			req.payload = req.RawBody
+		} else {
+			// Marshal body to JSON
+			resolved, err := json.Marshal(req.Body)
+			if err != nil {
+				return errors.New("INVALID BODY: failed to marshal JSON: " + err.Error())
+			}
+			req.payload = resolved
 		}
 
-		req.ResolvedBody = bytes.NewBuffer(resolved)
+		req.ResolvedBody = bytes.NewBuffer(req.payload)
 	}
 
 	return nil
@@ -120,6 +125,46 @@ func (req *Request) LogRequest() string {
 		headerCount)
 }
 
+// RegisterService adds or replaces the base URL of a service
+// Intended for services configured at startup, before requests are made
+func RegisterService(service Service, baseUrl string) {
+	SERVICES[service] = strings.TrimSuffix(baseUrl, "/")
+}
+
+// RegisterSensitiveService registers a service whose request paths carry
+// credentials (e.g. a bot token); logs show its base URL only
+func RegisterSensitiveService(service Service, baseUrl string) {
+	RegisterService(service, baseUrl)
+	sensitiveServices[service] = true
+}
+
+// redactURL hides the credentials a request URL may carry before it is logged
+// The path of a sensitive service and the query string are replaced
+func redactURL(service Service, url string) string {
+	if sensitiveServices[service] {
+		if base := SERVICES[service]; strings.HasPrefix(url, base) {
+			return base + "/" + redactedValue
+		}
+		return "/" + redactedValue
+	}
+	if i := strings.IndexByte(url, '?'); i >= 0 {
+		return url[:i+1] + redactedValue
+	}
+	return url
+}
+
+// redactHeader hides the value of headers carrying credentials before they are logged
+func redactHeader(name, value string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case lower == "authorization", lower == "proxy-authorization", lower == "cookie",
+		strings.Contains(lower, "token"), strings.Contains(lower, "key"),
+		strings.Contains(lower, "secret"), strings.Contains(lower, "signature"):
+		return redactedValue
+	}
+	return value
+}
+
 // ValidateServiceURL checks if a service and URL combination is valid
 func ValidateServiceURL(service Service, url string) error {
 	if _, exists := SERVICES[service]; !exists {
@@ -1,9 +1,12 @@
 package hermes
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
 	"sync"
 	"time"
 
@@ -25,7 +28,7 @@ func (h *HermesClient) Fetch(req *Request) Response {
 	requestFields := map[string]interface{}{
 		"service": string(req.Service),
 		"method":  req.Method.String(),
-		"url":     req.Url,
+		"url":     redactURL(req.Service, req.Url),
 	}
 
 	h.logger.Debug("Processing request", requestFields)
@@ -35,7 +38,7 @@ func (h *HermesClient) Fetch(req *Request) Response {
 			"error":   err.Error(),
 			"service": string(req.Service),
 			"method":  req.Method.String(),
-			"url":     req.Url,
+			"url":     redactURL(req.Service, req.Url),
 		})
 		return Response{
 			Success: false,
@@ -65,7 +68,7 @@ func (h *HermesClient) Fetch(req *Request) Response {
 			h.logger.Warn(fmt.Sprintf("Retrying request (attempt %d/%d)", attempts, h.retries), map[string]interface{}{
 				"service":     string(req.Service),
 				"method":      req.Method.String(),
-				"url":         url,
+				"url":         redactURL(req.Service, url),
 				"retry_delay": retryDelay.String(),
 				"prev_error":  finalErr.Error(),
 			})
@@ -93,7 +96,7 @@ func (h *HermesClient) Fetch(req *Request) Response {
 			"error":       finalErr.Error(),
 			"service":     string(req.Service),
 			"method":      req.Method.String(),
-			"url":         url,
+			"url":         redactURL(req.Service, url),
 			"attempts":    attempts,
 			"max_retries": h.retries,
 		})
@@ -107,7 +110,7 @@ func (h *HermesClient) Fetch(req *Request) Response {
 	h.logger.Debug("Request completed successfully", map[string]interface{}{
 		"service":       string(req.Service),
 		"method":        req.Method.String(),
-		"url":           url,
+		"url":           redactURL(req.Service, url),
 		"status_code":   statusCode,
 		"response_size": len(responseData),
 		"attempts":      attempts + 1,
@@ -123,13 +126,19 @@ func (h *HermesClient) Fetch(req *Request) Response {
 
 // createRequest creates a new HTTP request
 func (h *HermesClient) createRequest(req *Request, url string) (*http.Request, error) {
-	request, err := http.NewRequest(req.Method.String(), url, req.ResolvedBody)
+	// A fresh reader per attempt, since a previous attempt drains the body
+	var body io.Reader
+	if req.payload != nil {
+		body = bytes.NewReader(req.payload)
+	}
+
+	request, err := http.NewRequest(req.Method.String(), url, body)
 	if err != nil {
 		h.logger.Error("Failed to create HTTP request", map[string]interface{}{
 			"error":   err.Error(),
 			"service": string(req.Service),
 			"method":  req.Method.String(),
-			"url":     url,
+			"url":     redactURL(req.Service, url),
 		})
 		return nil, err
 	}
@@ -138,7 +147,7 @@ func (h *HermesClient) createRequest(req *Request, url string) (*http.Request, e
 	headerFields := make(map[string]interface{})
 	for key, value := range *req.Headers {
 		request.Header.Set(key, value)
-		headerFields[key] = value
+		headerFields[key] = redactHeader(key, value)
 	}
 	h.logger.Debug("Request headers", headerFields)
 
@@ -154,7 +163,7 @@ func (h *HermesClient) executeRequest(request *http.Request, url string, req *Re
 	h.logger.Info("Sending request", map[string]interface{}{
 		"service":    string(req.Service),
 		"method":     req.Method.String(),
-		"url":        url,
+		"url":        redactURL(req.Service, url),
 		"timeout_ms": client.Timeout.Milliseconds(),
 	})
 
@@ -163,11 +172,16 @@ func (h *HermesClient) executeRequest(request *http.Request, url string, req *Re
 	duration := time.Since(startTime)
 
 	if err != nil {
+		// Transport errors quote the URL
+		var urlErr *neturl.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = redactURL(req.Service, urlErr.URL)
+		}
 		h.logger.Error("Request failed", map[string]interface{}{
 			"error":       err.Error(),
 			"service":     string(req.Service),
 			"method":      req.Method.String(),
-			"url":         url,
+			"url":         redactURL(req.Service, url),
 			"duration_ms": duration.Milliseconds(),
 		})
 		return nil, 0, err
@@ -176,7 +190,7 @@ func (h *HermesClient) executeRequest(request *http.Request, url string, req *Re
 	h.logger.Info("Response received", map[string]interface{}{
 		"service":      string(req.Service),
 		"method":       req.Method.String(),
-		"url":          url,
+		"url":          redactURL(req.Service, url),
 		"status_code":  response.StatusCode,
 		"duration_ms":  duration.Milliseconds(),
 		"content_type": response.Header.Get("Content-Type"),
@@ -190,7 +204,7 @@ func (h *HermesClient) executeRequest(request *http.Request, url string, req *Re
 			"error":       err.Error(),
 			"service":     string(req.Service),
 			"method":      req.Method.String(),
-			"url":         url,
+			"url":         redactURL(req.Service, url),
 			"status_code": response.StatusCode,
 		})
 		return nil, response.StatusCode, err
@@ -199,7 +213,7 @@ func (h *HermesClient) executeRequest(request *http.Request, url string, req *Re
 	h.logger.Debug("Response completed", map[string]interface{}{
 		"service":       string(req.Service),
 		"method":        req.Method.String(),
-		"url":           url,
+		"url":           redactURL(req.Service, url),
 		"status_code":   response.StatusCode,
 		"response_size": len(data),
 		"duration_ms":   duration.Milliseconds(),
@@ -263,7 +277,7 @@ func IsRetryableError(statusCode int, err error) bool {
 func (h *HermesClient) Get(service Service, url string, headers *map[string]string) Response {
 	h.logger.Debug("Creating GET request", map[string]interface{}{
 		"service": string(service),
-		"url":     url,
+		"url":     redactURL(service, url),
 	})
 
 	req := &Request{
@@ -280,7 +294,7 @@ func (h *HermesClient) Get(service Service, url string, headers *map[string]stri
 func (h *HermesClient) Post(service Service, url string, body *map[string]any, headers *map[string]string) Response {
 	h.logger.Debug("Creating POST request", map[string]interface{}{
 		"service": string(service),
-		"url":     url,
+		"url":     redactURL(service, url),
 		"body":    body != nil,
 	})
 
@@ -299,7 +313,7 @@ func (h *HermesClient) Post(service Service, url string, body *map[string]any, h
 func (h *HermesClient) Put(service Service, url string, body *map[string]any, headers *map[string]string) Response {
 	h.logger.Debug("Creating PUT request", map[string]interface{}{
 		"service": string(service),
-		"url":     url,
+		"url":     redactURL(service, url),
 		"body":    body != nil,
 	})
 
@@ -318,7 +332,7 @@ func (h *HermesClient) Put(service Service, url string, body *map[string]any, he
 func (h *HermesClient) Delete(service Service, url string, headers *map[string]string) Response {
 	h.logger.Debug("Creating DELETE request", map[string]interface{}{
 		"service": string(service),
-		"url":     url,
+		"url":     redactURL(service, url),
 	})
 
 	req := &Request{
@@ -335,7 +349,7 @@ func (h *HermesClient) Delete(service Service, url string, headers *map[string]s
 func (h *HermesClient) Patch(service Service, url string, body *map[string]any, headers *map[string]string) Response {
 	h.logger.Debug("Creating PATCH request", map[string]interface{}{
 		"service": string(service),
-		"url":     url,
+		"url":     redactURL(service, url),
 		"body":    body != nil,
 	})
 